@@ -6,4 +6,127 @@ window.htmx = require('htmx.org');
 // HTMX config.
 htmx.config.selfRequestsOnly = true;
 htmx.config.globalViewTransitions = true;
-htmx.config.historyEnabled = false;
\ No newline at end of file
+htmx.config.historyEnabled = false;
+
+// Send the exact expiration and availability datetimes from the date pickers with the time zone of the browser.
+document.addEventListener('htmx:configRequest', (event) => {
+    for (const name of ['expires_at_datetime', 'available_at']) {
+        const datetime = event.detail.parameters[name];
+        if (datetime) {
+            event.detail.parameters[name] = new Date(datetime).toISOString();
+        }
+    }
+});
+
+// Open the dialog, when its content is loaded, and close it, when the content is cleared (for example, after the submit).
+document.addEventListener('htmx:afterSwap', (event) => {
+    const dialog = event.detail.target;
+    if (!dialog.matches('dialog')) {
+        return;
+    }
+
+    if (dialog.childElementCount > 0) {
+        dialog.showModal();
+    } else {
+        dialog.close();
+    }
+});
+
+/*
+    Zero-knowledge mode.
+
+    The secret value is encrypted in the browser with WebCrypto (AES-GCM) before it is sent
+    to the server, and the decryption key travels only in the #fragment of the share URL,
+    which is never sent to the server by the browser.
+*/
+
+// Encode bytes to the base64url string.
+const toBase64URL = (bytes) =>
+    btoa(String.fromCharCode(...new Uint8Array(bytes))).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+
+// Decode the base64url string to bytes.
+const fromBase64URL = (text) =>
+    Uint8Array.from(atob(text.replace(/-/g, '+').replace(/_/g, '/')), (c) => c.charCodeAt(0));
+
+// Encrypt the text with a new random key, returns the base64url encrypted value and key.
+const encryptInBrowser = async (text) => {
+    const key = await crypto.subtle.generateKey({ name: 'AES-GCM', length: 256 }, true, ['encrypt']);
+    const iv = crypto.getRandomValues(new Uint8Array(12));
+    const ciphertext = await crypto.subtle.encrypt({ name: 'AES-GCM', iv }, key, new TextEncoder().encode(text));
+    const rawKey = await crypto.subtle.exportKey('raw', key);
+
+    return {
+        value: toBase64URL([...iv, ...new Uint8Array(ciphertext)]),
+        key: toBase64URL(rawKey),
+    };
+};
+
+// Decrypt the base64url encrypted value with the base64url key.
+const decryptInBrowser = async (value, rawKey) => {
+    const key = await crypto.subtle.importKey('raw', fromBase64URL(rawKey), { name: 'AES-GCM' }, false, ['decrypt']);
+    const data = fromBase64URL(value);
+    const text = await crypto.subtle.decrypt({ name: 'AES-GCM', iv: data.slice(0, 12) }, key, data.slice(12));
+
+    return new TextDecoder().decode(text);
+};
+
+// Encrypt the secret value before the add secret request, if the zero-knowledge mode is checked.
+document.addEventListener('htmx:confirm', (event) => {
+    const form = event.detail.elt;
+    if (!form.matches('form[data-zero-knowledge]') || !form.elements['is_client_encrypted'].checked) {
+        return;
+    }
+
+    event.preventDefault();
+    encryptInBrowser(form.elements['value'].value).then(({ value, key }) => {
+        form.dataset.encryptedValue = value;
+        form.dataset.encryptedKey = key;
+        event.detail.issueRequest();
+    });
+});
+
+// Replace the plain secret value with the encrypted one in the request parameters.
+document.addEventListener('htmx:configRequest', (event) => {
+    const form = event.detail.elt;
+    if (form.dataset.encryptedValue) {
+        event.detail.parameters['value'] = form.dataset.encryptedValue;
+        delete form.dataset.encryptedValue;
+    }
+});
+
+// Keep the decryption key in the session storage of the tab to show it on the share page.
+document.addEventListener('htmx:afterRequest', (event) => {
+    const form = event.detail.elt;
+    const location = event.detail.xhr.getResponseHeader('HX-Location');
+    if (form.dataset.encryptedKey && event.detail.successful && location) {
+        const key = new URL(location, window.location.origin).pathname.split('/').pop();
+        sessionStorage.setItem(`secretium:key:${key}`, form.dataset.encryptedKey);
+    }
+    delete form.dataset.encryptedKey;
+});
+
+htmx.onLoad((content) => {
+    // Append the decryption key to the share URL as the #fragment.
+    content.querySelectorAll('[data-client-encrypted-key]').forEach((element) => {
+        const key = sessionStorage.getItem(`secretium:key:${element.dataset.clientEncryptedKey}`);
+        if (key) {
+            element.value = `${element.value}#${key}`;
+        } else {
+            document.getElementById('client-encrypted-key-missing')?.classList.remove('hidden');
+        }
+    });
+
+    // Warn, if the share URL has no decryption key in the #fragment.
+    content.querySelectorAll('[data-client-encrypted-key-required]').forEach((element) => {
+        if (!window.location.hash) {
+            element.classList.remove('hidden');
+        }
+    });
+
+    // Decrypt the secret value with the decryption key from the #fragment of the URL.
+    content.querySelectorAll('[data-client-encrypted-value]').forEach((element) => {
+        decryptInBrowser(element.dataset.clientEncryptedValue, window.location.hash.slice(1))
+            .then((text) => (element.textContent = text))
+            .catch(() => (element.textContent = 'Unable to decrypt: the share link is incomplete or the decryption key is wrong.'));
+    });
+});
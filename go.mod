@@ -5,6 +5,8 @@ go 1.23.0
 toolchain go1.24.1
 
 require (
+	filippo.io/age v1.2.1
+	github.com/ProtonMail/go-crypto v1.3.0
 	github.com/a-h/templ v0.3.943
 	github.com/alexedwards/scs/v2 v2.9.0
 	github.com/boombuler/barcode v1.1.0
@@ -12,4 +14,10 @@ require (
 	github.com/jmoiron/sqlx v1.4.0
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/mattn/go-sqlite3 v1.14.32
+	golang.org/x/crypto v0.41.0
+)
+
+require (
+	github.com/cloudflare/circl v1.6.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
 )
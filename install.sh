@@ -22,15 +22,41 @@ services:
       - '8787'
     # Set required environment variables for the backend.
     environment:
-      SECRET_KEY: /run/secrets/secretium_key
-      MASTER_USERNAME: /run/secrets/secretium_master_username
-      MASTER_PASSWORD: /run/secrets/secretium_master_password
-      DOMAIN: /run/secrets/secretium_domain
+      KEY_PROVIDER: env # or 'file' (reads only SECRET_KEY_FILE), or 'vault' (uses the HashiCorp Vault transit engine)
+      SECRET_KEY_FILE: /run/secrets/secretium_key
+      # VAULT_ADDR: https://vault.example.com:8200 # for the 'vault' key provider
+      # VAULT_TOKEN_FILE: /run/secrets/secretium_vault_token
+      # VAULT_TRANSIT_MOUNT: transit
+      # VAULT_TRANSIT_KEY: secretium
+      MASTER_USERNAME_FILE: /run/secrets/secretium_master_username
+      MASTER_PASSWORD_FILE: /run/secrets/secretium_master_password
+      DOMAIN_FILE: /run/secrets/secretium_domain
       DOMAIN_SCHEMA: https
       SERVER_PORT: 8787 # same as the exposed container port
       SERVER_TIMEZONE: Europe/Moscow
       SERVER_READ_TIMEOUT: 5
       SERVER_WRITE_TIMEOUT: 10
+      ACCESS_CODE_TYPE: characters # or 'passphrase' (random words, easy to read aloud)
+      ACCESS_CODE_LENGTH: 10 # for 'characters', from 6 to 64
+      ACCESS_CODE_CHARACTER_CLASSES: lower,digits # any of 'lower', 'upper', 'digits', 'symbols'
+      ACCESS_CODE_EXCLUDE_AMBIGUOUS: true # exclude characters that are easy to confuse
+      ACCESS_CODE_WORDS: 4 # for 'passphrase', from 3 to 10
+      ACCESS_CODE_WORD_SEPARATOR: '-' # for 'passphrase', any of '-', '.', '_' or ' '
+      SECRET_MIN_TTL: 5m # the shortest expiration time of the secrets, from 1m
+      SECRET_MAX_TTL: 720h # the longest expiration time of the secrets
+      SECRET_MAX_LIFETIME: 0 # the longest total lifetime of the secrets through renewals, or 0 for unlimited
+      FAILED_ATTEMPTS_LIMIT: 0 # unlimited, or failed access code attempts before the action (for example, 5)
+      FAILED_ATTEMPTS_ACTION: lock # until unlocked in the dashboard, or 'destroy' (deletes the secret)
+      SECRET_FILES_MAX_SIZE: 10 # the largest total size of the files attached to a secret in MiB, or 0 to disable files
+      JANITOR_MODE: 'off' # or 'delete' (deletes the expired secrets), or 'wipe' (keeps only their name, key and dates)
+      JANITOR_RETENTION_PERIOD: 720h # how long the expired secrets are kept before purging
+      JANITOR_INTERVAL: 1h # from 1m
+    # Set the Docker secrets for the container (mounted to the '/run/secrets' folder).
+    secrets:
+      - secretium_key
+      - secretium_master_username
+      - secretium_master_password
+      - secretium_domain
     # Set volumes for the container with SQLite data and the root SSL certificates.
     volumes:
       - ./secretium-data:/secretium-data
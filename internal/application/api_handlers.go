@@ -1,11 +1,18 @@
 package application
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/secretium/secretium/internal/constants"
 	"github.com/secretium/secretium/internal/database"
 	"github.com/secretium/secretium/internal/helpers"
 	"github.com/secretium/secretium/internal/messages"
@@ -16,8 +23,18 @@ import (
 
 // APIAddSecretHandler adds a new secret to the database (POST).
 func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	// Parse the form data.
-	if err := r.ParseForm(); err != nil {
+	// Parse the form data (the attached files are encrypted to disk while they are uploaded).
+	uploads, err := a.parseAddSecretForm(w, r)
+
+	// Make sure to remove the uploaded files, if the secret was not added.
+	isAdded := false
+	defer func() {
+		if !isAdded {
+			a.removeSecretFileUploads(uploads)
+		}
+	}()
+
+	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
@@ -35,10 +52,37 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 	name := r.FormValue("name")
 	value := r.FormValue("value")
 	expiresAt := r.FormValue("expires_at")
-	isExpireAfterFirstUnlock := r.FormValue("is_expire_after_first_unlock") == "on"
+	isClientEncrypted := r.FormValue("is_client_encrypted") == "on"
+	isAccessCodeProtected := r.FormValue("is_access_code_protected") == "on"
+	customAccessCode := r.FormValue("access_code")
+	reissueKey := r.FormValue("reissue_key")
+	recipientPublicKey := strings.TrimSpace(r.FormValue("recipient_public_key"))
+
+	// Get the maximum number of views of the secret (an empty value means, that the secret has unlimited views).
+	// The legacy "expire after first unlock" flag is the same as one view.
+	maxViews, _ := strconv.Atoi(r.FormValue("max_views"))
+	if r.FormValue("is_expire_after_first_unlock") == "on" {
+		maxViews = 1
+	}
+
+	// Get the number of shares and the threshold of the split secret (empty values mean, that the secret is not split).
+	sharesTotal, _ := strconv.Atoi(r.FormValue("shares_total"))
+	sharesThreshold, _ := strconv.Atoi(r.FormValue("shares_threshold"))
 
 	// Check, if the form values are valid.
-	if err := helpers.ValidateAddSecretForm(name, value); err != nil {
+	if err := append(
+		helpers.ValidateAddSecretForm(name, value, customAccessCode, isClientEncrypted),
+		append(
+			helpers.ValidateSplitSecretForm(sharesTotal, sharesThreshold, customAccessCode, isClientEncrypted, isAccessCodeProtected),
+			append(
+				helpers.ValidateRecipientSecretForm(recipientPublicKey, isClientEncrypted),
+				append(
+					helpers.ValidateMaxViewsSecretForm(maxViews),
+					helpers.ValidateSecretFilesForm(len(uploads), sharesTotal, recipientPublicKey, isClientEncrypted)...,
+				)...,
+			)...,
+		)...,
+	); err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
@@ -48,24 +92,46 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Get the access code policy of the secret (the instance policy is used, if the form has no policy).
+	accessCodePolicy := a.Config.AccessCodePolicy
+	if r.FormValue("access_code_type") != "" {
+		policy, err := helpers.ParseAccessCodePolicy(
+			r.FormValue("access_code_type"),
+			r.FormValue("access_code_length"),
+			strings.Join(r.Form["access_code_character_classes"], ","),
+			r.FormValue("access_code_exclude_ambiguous"),
+			r.FormValue("access_code_words"),
+			r.FormValue("access_code_word_separator"),
+		)
+		if err != nil {
+			// Wrap the error with template.
+			helpers.WrapHTTPError(
+				w, r, http.StatusBadRequest,
+				components.FormValidationError(
+					[]*messages.ErrorField{
+						{Name: "Access code", Message: err.Error()},
+					},
+				),
+				err.Error(),
+			)
+			return
+		}
+		accessCodePolicy = policy
+	}
+
 	// Get current date and time.
 	createdAt := time.Now()
 
-	// Create a new hashed access code string with salt and trim it to 8 characters.
-	accessCodeHashed := helpers.HashString(8, fmt.Sprintf("%d", createdAt.Unix()), a.Config.SecretKey)
-
-	// Create a new hashed key string with salt and trim it to 16 characters.
-	keyHashed := helpers.HashString(16, accessCodeHashed, a.Config.SecretKey)
-
-	// Encrypt the secret value.
-	valueEncrypted, err := helpers.EncryptString(a.Config.SecretKey, value)
+	// Parse the 'available_at' datetime (the secret is available right away, if it is not set).
+	// It can be scheduled for at most the maximum TTL since now.
+	availableAt, err := helpers.ParseAvailableDatetime(createdAt, r.FormValue("available_at"), a.Config.SecretTTL.Max)
 	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Encrypt secret", Message: err.Error()},
+					{Name: "Available datetime", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -73,15 +139,22 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Encrypt the access code value.
-	accessCodeEncrypted, err := helpers.EncryptString(a.Config.SecretKey, accessCodeHashed)
+	// Parse the 'expires_at' datetime (the exact datetime from the date picker has priority over the duration).
+	// The duration is counted since the secret becomes available, and the total lifetime since its creation (as on renewal).
+	if expiresAtDatetime := r.FormValue("expires_at_datetime"); expiresAtDatetime != "" {
+		expiresAt = expiresAtDatetime
+	}
+	expiresAtDuration, err := helpers.ParseExpiresDatetime(availableAt, expiresAt, a.Config.SecretTTL.Min, a.Config.SecretTTL.Max)
+	if err == nil {
+		err = helpers.CheckSecretLifetime(createdAt, expiresAtDuration, a.Config.SecretTTL.MaxLifetime)
+	}
 	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Encrypt access code", Message: err.Error()},
+					{Name: "Expires datetime", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -89,15 +162,86 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Parse the 'expires_at' datetime.
-	expiresAtDuration, err := helpers.ExpiresDatetimeSwitcher(createdAt, expiresAt)
+	// Create a new secret record.
+	secret := &database.Secret{
+		CreatedAt:                createdAt,
+		AvailableAt:              availableAt,
+		ExpiresAt:                expiresAtDuration,
+		Name:                     name,
+		IsExpireAfterFirstUnlock: maxViews == 1,
+		MaxViews:                 maxViews,
+		RemainingViews:           maxViews,
+		IsClientEncrypted:        isClientEncrypted,
+		IsAccessCodeProtected:    isAccessCodeProtected,
+		SharesTotal:              sharesTotal,
+		SharesThreshold:          sharesThreshold,
+	}
+
+	// Encrypt the secret value to the recipient public key, if it is set.
+	// The armored ciphertext is stored as the secret value, so only the recipient can decrypt it after unlock.
+	if recipientPublicKey != "" {
+		secret.RecipientType, secret.Recipient, value, err = helpers.EncryptToRecipient(recipientPublicKey, value)
+		if err != nil {
+			// Wrap the error with template.
+			helpers.WrapHTTPError(
+				w, r, http.StatusBadRequest,
+				components.FormValidationError(
+					[]*messages.ErrorField{
+						{Name: "Recipient public key", Message: err.Error()},
+					},
+				),
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Add the record to the database with a new random access code and key.
+	// The access code and key are generated again, if they are already taken by another secret.
+	var accessCodes []string
+	var shares []*database.SecretShare
+	for attempt := 1; ; attempt++ {
+		// Create the access code and key, and encrypt the secret value.
+		// The split secret has no access code of its own, each share has its own access code and key instead.
+		if secret.SharesTotal > 0 {
+			shares, accessCodes, err = a.encryptSplitSecret(secret, value, accessCodePolicy)
+		} else {
+			var accessCode string
+			accessCode, err = a.encryptNewSecret(secret, value, customAccessCode, accessCodePolicy)
+			accessCodes = []string{accessCode}
+
+			// Wrap the keys of the attached files like the data key of the secret.
+			if err == nil {
+				err = a.wrapSecretFileKeys(secret, uploads, accessCode)
+			}
+		}
+		if err != nil {
+			// Wrap the error with template.
+			helpers.WrapHTTPError(
+				w, r, http.StatusBadRequest,
+				components.FormValidationError(
+					[]*messages.ErrorField{
+						{Name: "Encrypt secret", Message: err.Error()},
+					},
+				),
+				err.Error(),
+			)
+			return
+		}
+
+		// Add the record to the database.
+		err = a.Database.QueryAddSecret(secret, shares...)
+		if !errors.Is(err, database.ErrSecretIsNotUnique) || attempt == constants.ConstSecretAddMaxAttempts {
+			break
+		}
+	}
 	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Expires datetime", Message: err.Error()},
+					{Name: "Add secret", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -105,25 +249,66 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Create a new secret record.
-	secret := &database.Secret{
-		CreatedAt:                createdAt,
-		ExpiresAt:                expiresAtDuration,
-		Name:                     name,
-		AccessCode:               accessCodeEncrypted,
-		Key:                      keyHashed,
-		Value:                    valueEncrypted,
-		IsExpireAfterFirstUnlock: isExpireAfterFirstUnlock,
+	// Keep the uploaded files of the added secret.
+	isAdded = true
+	for _, upload := range uploads {
+		a.secretFiles.release(upload.StorageKey)
+	}
+
+	// Delete the re-issued secret, if the new secret replaces it (its files are removed from disk by the sweep).
+	if reissueKey != "" && helpers.IsSecretKeyValid(reissueKey, 16) == nil {
+		if err := a.Database.QueryDeleteSecretByKey(reissueKey); err != nil {
+			slog.Error("failed to delete re-issued secret", "key", reissueKey, "details", err.Error())
+		}
+		a.sweepSecretFiles()
+	}
+
+	// Redirect to the share secret page.
+	w.Header().Set("HX-Location", fmt.Sprintf("/dashboard/share/%s?%s", secret.Key, url.Values{"access_code": accessCodes}.Encode()))
+}
+
+// APIUnlockSecretHandler renders the unlocked secret block (POST).
+func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Parse the form data.
+	if err := r.ParseForm(); err != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Render the encrypt secret error.
+		_ = components.FormValidationError(
+			[]*messages.ErrorField{
+				{Name: "Form data", Message: err.Error()},
+			},
+		).Render(r.Context(), w)
+
+		return
+	}
+
+	// Get key and access code from the form inputs.
+	accessCode := r.FormValue("access_code")
+
+	// Check, if the form values are valid.
+	if err := helpers.ValidateViewSecretForm(accessCode); err != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Render the form validation error.
+		_ = components.FormValidationError(err).Render(r.Context(), w)
+
+		return
 	}
 
-	// Add the record to the database.
-	if err := a.Database.QueryAddSecret(secret); err != nil {
+	// Check, if the secret is unlocked from its page in the same browser (before anything is consumed).
+	if err := a.verifyRevealToken(r, key, r.FormValue("reveal_token")); err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Expires datetime", Message: err.Error()},
+					{Name: "Unlock secret", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -131,12 +316,175 @@ func (a *Application) APIAddSecretHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Redirect to the share secret page.
-	w.Header().Set("HX-Location", fmt.Sprintf("/dashboard/share/%s?access_code=%s", secret.Key, accessCodeHashed))
+	// Create template options.
+	templateOptions := &templates.TemplateOptions{
+		Header: &templates.ElementStyle{},
+		Main: &templates.ElementStyle{
+			CSSClass: "secret",
+		},
+		Footer: &templates.ElementStyle{
+			CSSClass: "secret",
+		},
+	}
+
+	// Keep the encrypted secret to upgrade it to the active key after unlock.
+	var encryptedSecret database.Secret
+
+	// Keep the keys of the secret files to grant their download after unlock.
+	var fileKeys map[int][]byte
+
+	// Unlock the secret by its key from the database.
+	// One view of the secret is used only after the access code is verified, and the secret is expired after the last view.
+	secret, err := a.Database.QueryUnlockSecretByKey(key, time.Now().Local(), func(s *database.Secret) error {
+		// Copy the encrypted secret.
+		encryptedSecret = *s
+
+		// Check, if the secret is split into shares (it can only be unlocked by its shares).
+		if s.SharesTotal > 0 {
+			return errors.New(messages.ErrSecretIsSplit)
+		}
+
+		// Check, if the secret is protected by the access code.
+		if s.IsAccessCodeProtected {
+			// Verify the access code and decrypt the secret value.
+			decryptedValue, err := a.decryptProtectedSecretValue(s, accessCode)
+			if err != nil {
+				return err
+			}
+
+			// Set component options.
+			s.Value = decryptedValue
+		} else {
+			// Check, if the entered access code matches the access code of the secret.
+			if err := a.verifySecretAccessCode(s, accessCode); err != nil {
+				return err
+			}
+
+			// Decrypt the secret value.
+			decryptedValue, err := a.decryptSecretValue(s)
+			if err != nil {
+				return err
+			}
+
+			// Set component options.
+			s.Value = decryptedValue
+		}
+
+		// Unwrap the keys of the secret files (the view is not used, if the files cannot be granted).
+		files, keys, err := a.unlockSecretFiles(s, accessCode)
+		if err != nil {
+			return err
+		}
+		s.Files, fileKeys = files, keys
+
+		return nil
+	})
+
+	// Count the failed attempt, if the access code is wrong (the secret is locked or destroyed after too many attempts).
+	err = a.countFailedAttempt(key, err)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Send a 404 not found response.
+		w.WriteHeader(http.StatusNotFound)
+
+		// Set the key to the secret, because the secret is not found (Secret struct has zero values).
+		secret.Key = key
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is not found"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "not-found")
+
+		// Render the secret page with 404 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsExpired):
+		// Send a 400 not found response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is expired"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "expired")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsNotAvailable):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Secret is not available yet"
+		templateOptions.Component = pages.Secret(&secret, "scheduled")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsLocked):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is locked"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "locked-out")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsDestroyed):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is destroyed"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "destroyed")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case err != nil:
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(
+				[]*messages.ErrorField{
+					{Name: "Unlock secret", Message: err.Error()},
+				},
+			),
+			err.Error(),
+		)
+		return
+	}
+
+	// Revoke the reveal token, because the secret is unlocked.
+	a.revokeRevealToken(r, key)
+
+	// Grant the download of the secret files in the same browser.
+	a.grantSecretFiles(r, key, fileKeys)
+
+	// Upgrade the encrypted fields of the secret to the active key.
+	if a.isSecretOutdated(&encryptedSecret) {
+		if err := a.upgradeSecret(&encryptedSecret); err != nil {
+			slog.Error("failed to upgrade encrypted fields", "key", key, "details", err.Error())
+		}
+	}
+
+	// Render the secret page.
+	_ = pages.Secret(&secret, "unlocked").Render(r.Context(), w)
 }
 
-// APIUnlockSecretHandler renders the unlocked secret block (POST).
-func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+// APIUnlockSecretShareHandler accepts the share of the split secret, and renders the unlocked secret block,
+// if enough shares are submitted (POST).
+func (a *Application) APIUnlockSecretShareHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	// Get key from the URL.
 	key := params.ByName("key")
 
@@ -155,7 +503,7 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Get key and access code from the form inputs.
+	// Get access code from the form inputs.
 	accessCode := r.FormValue("access_code")
 
 	// Check, if the form values are valid.
@@ -169,6 +517,21 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Check, if the secret is unlocked from its page in the same browser (before anything is consumed).
+	if err := a.verifyRevealToken(r, key, r.FormValue("reveal_token")); err != nil {
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(
+				[]*messages.ErrorField{
+					{Name: "Unlock share", Message: err.Error()},
+				},
+			),
+			err.Error(),
+		)
+		return
+	}
+
 	// Create template options.
 	templateOptions := &templates.TemplateOptions{
 		Header: &templates.ElementStyle{},
@@ -180,8 +543,12 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		},
 	}
 
-	// Add the secret to the database.
-	secret, err := a.Database.QueryGetSecretByKey(key)
+	// Get the share by its key and the split secret by its ID from the database.
+	var secret database.Secret
+	share, err := a.Database.QueryGetSecretShareByKey(key)
+	if err == nil {
+		secret, err = a.Database.QueryGetSecretByID(share.SecretID)
+	}
 	if err != nil {
 		// Send a 404 not found response.
 		w.WriteHeader(http.StatusNotFound)
@@ -200,8 +567,8 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Check, if expiration date is in the future.
-	if secret.ExpiresAt.Before(time.Now()) {
+	// Check, if the secret is expired.
+	if !helpers.DatetimeChecker(secret.ExpiresAt.Local(), time.Now().Local()) {
 		// Send a 400 not found response.
 		w.WriteHeader(http.StatusBadRequest)
 
@@ -216,15 +583,210 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Decrypt the access code value.
-	accessCodeDecrypted, err := helpers.DecryptString(a.Config.SecretKey, secret.AccessCode)
+	// Check, if the secret is not available yet (the share is not added to the pool before that time).
+	if secret.AvailableAt.After(time.Now()) {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Secret is not available yet"
+		templateOptions.Component = pages.Secret(&secret, "scheduled")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Check, if the secret is locked after too many failed access code attempts.
+	if secret.LockedAt != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is locked"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "locked-out")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Verify the access code and decrypt the share.
+	decryptedShare, err := a.decryptSecretShare(&share, accessCode)
+
+	// Count the failed attempt for the split secret, if the access code of the share is wrong.
+	err = a.countFailedAttempt(secret.Key, err)
+
+	switch {
+	case errors.Is(err, database.ErrSecretIsLocked):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is locked"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "locked-out")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsDestroyed):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is destroyed"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "destroyed")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case err != nil:
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(
+				[]*messages.ErrorField{
+					{Name: "Unlock share", Message: err.Error()},
+				},
+			),
+			err.Error(),
+		)
+		return
+	}
+
+	// Revoke the reveal token, because the share is accepted.
+	a.revokeRevealToken(r, key)
+
+	// Add the decrypted share to the pool of the submitted shares.
+	shares, submittedShares := a.sharePool.add(
+		secret.ID, share.Number, decryptedShare, secret.SharesThreshold,
+		time.Duration(constants.ConstSecretSharesPoolTTL)*time.Minute,
+	)
+	if shares == nil {
+		// Render the accepted share block, because the threshold is not reached yet.
+		_ = pages.SecretShare(&secret, &share, "accepted", submittedShares, "").Render(r.Context(), w)
+		return
+	}
+
+	// Clear the decrypted shares, after the secret is unlocked.
+	defer func() {
+		for _, s := range shares {
+			clear(s)
+		}
+	}()
+
+	// Unlock the split secret by its key from the database.
+	// The secret value is assembled from the submitted shares in memory only.
+	secret, err = a.Database.QueryUnlockSecretByKey(secret.Key, time.Now().Local(), func(s *database.Secret) error {
+		// Decrypt the secret value with the data key assembled from the shares.
+		decryptedValue, err := a.decryptSplitSecretValue(s, shares)
+		if err != nil {
+			return err
+		}
+
+		// Set component options.
+		s.Value = decryptedValue
+
+		return nil
+	})
+	switch {
+	case errors.Is(err, database.ErrSecretIsExpired):
+		// Send a 400 not found response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is expired"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "expired")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case errors.Is(err, database.ErrSecretIsNotAvailable):
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Set the template options.
+		templateOptions.PageTitle = "Secret is not available yet"
+		templateOptions.Component = pages.Secret(&secret, "scheduled")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	case err != nil:
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(
+				[]*messages.ErrorField{
+					{Name: "Unlock secret", Message: err.Error()},
+				},
+			),
+			err.Error(),
+		)
+		return
+	}
+
+	// Render the secret page.
+	_ = pages.Secret(&secret, "unlocked").Render(r.Context(), w)
+}
+
+// upgradeSecret re-encrypts the encrypted fields of the given secret with the active key and updates it in the database.
+func (a *Application) upgradeSecret(secret *database.Secret) error {
+	// Re-encrypt the encrypted fields of the secret.
+	rotation, err := a.reencryptSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	// Patch the record in the database.
+	_, err = a.Database.QueryRotateEncryptedFields([]*database.SecretRotation{rotation})
+
+	return err
+}
+
+// APIRenewSecretExpiresAtFieldByKeyHandler renews a secret 'expires_at' field by its key from the database (PATCH).
+// The new expiration time is one of the durations or the exact datetime, like on the secret creation (1 day by default).
+func (a *Application) APIRenewSecretExpiresAtFieldByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Check, if the current URL has a 'key' parameter with a valid secret key.
+	if err := helpers.IsSecretKeyValid(key, 16); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Get the secret by its key from the database.
+	secret, err := a.Database.QueryGetSecretByKey(key)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Parse the new 'expires_at' datetime.
+	renewedAt := time.Now()
+	expiresAtDuration, err := helpers.ParseExpiresDatetime(renewedAt, renewExpiresAt(r), a.Config.SecretTTL.Min, a.Config.SecretTTL.Max)
+	if err == nil {
+		// Check, if the total lifetime of the secret is not exceeded.
+		err = helpers.CheckSecretLifetime(secret.CreatedAt, expiresAtDuration, a.Config.SecretTTL.MaxLifetime)
+	}
 	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Decrypt access code", Message: err.Error()},
+					{Name: "Expires datetime", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -232,30 +794,252 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Check, if the entered access code is equal to the decrypted access code.
-	if accessCode != accessCodeDecrypted {
+	// Get the username of the current user.
+	renewedBy := a.sessionUsername(r)
+
+	// Patch the record by its key from the database.
+	if err := a.Database.QueryRenewSecretByKey(key, expiresAtDuration, renewedAt, renewedBy); err != nil {
+		// Send a 409 conflict response, if the secret was wiped by the janitor.
+		if errors.Is(err, database.ErrSecretIsWiped) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Log the renewal of the secret.
+	slog.Info("secret renewed", "key", key, "expires_at", expiresAtDuration.Format(time.RFC3339), "renewed_by", renewedBy)
+
+	// Set the HX-Trigger header (to trigger a re-render by htmx).
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
+}
+
+// renewExpiresAt returns the new expiration time of the renewal from the form inputs (the exact datetime
+// from the date picker has priority over the duration, 1 day by default).
+func renewExpiresAt(r *http.Request) string {
+	expiresAt := r.FormValue("expires_at")
+	if expiresAtDatetime := r.FormValue("expires_at_datetime"); expiresAtDatetime != "" {
+		expiresAt = expiresAtDatetime
+	}
+	if expiresAt == "" {
+		expiresAt = constants.ConstSecretRenewDefault
+	}
+
+	return expiresAt
+}
+
+// APIBulkSecretsHandler runs the bulk action (expire, renew, delete or purge all expired) for the selected secrets
+// by their keys, and renders the result for each key (POST).
+func (a *Application) APIBulkSecretsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Parse the form data.
+	if err := r.ParseForm(); err != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Render the bulk action error.
+		_ = components.DashboardBulkSecretsError(
+			[]*messages.ErrorField{
+				{Name: "Form data", Message: err.Error()},
+			},
+		).Render(r.Context(), w)
+
+		return
+	}
+
+	// Get the action and the keys of the selected secrets from the form inputs.
+	action := r.FormValue("action")
+	keys := uniqueBulkKeys(r.Form["keys"])
+
+	// Check, if the form values are valid.
+	if err := helpers.ValidateBulkSecretsForm(action, keys); err != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Render the bulk action error.
+		_ = components.DashboardBulkSecretsError(err).Render(r.Context(), w)
+
+		return
+	}
+
+	// Create the bulk action.
+	b := &bulkAction{Action: action, Keys: keys, Now: time.Now().Local(), Username: a.sessionUsername(r)}
+
+	// Parse the new 'expires_at' datetime for the renewal.
+	if action == constants.ConstBulkActionRenew {
+		expiresAt, err := helpers.ParseExpiresDatetime(b.Now, renewExpiresAt(r), a.Config.SecretTTL.Min, a.Config.SecretTTL.Max)
+		if err != nil {
+			// Wrap the error with template.
+			helpers.WrapHTTPError(
+				w, r, http.StatusBadRequest,
+				components.FormValidationError(
+					[]*messages.ErrorField{
+						{Name: "Expires datetime", Message: err.Error()},
+					},
+				),
+				err.Error(),
+			)
+			return
+		}
+		b.ExpiresAt = expiresAt
+	}
+
+	// Run the bulk action.
+	results, err := a.runBulkAction(b)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Set the HX-Trigger header (to trigger a re-render by htmx).
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
+
+	// Render the bulk action results block.
+	_ = components.DashboardBulkSecrets(action, results).Render(r.Context(), w)
+}
+
+// APIEditSecretByKeyHandler edits a secret name and value by its key in the database (PATCH).
+// The value is re-encrypted under the same key, and the previous version of the secret is kept for the rollback.
+// The empty value means, that only the name is edited.
+func (a *Application) APIEditSecretByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Check, if the current URL has a 'key' parameter with a valid secret key.
+	if err := helpers.IsSecretKeyValid(key, 16); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Get the secret by its key from the database.
+	secret, err := a.Database.QueryGetSecretByKey(key)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Send a 409 conflict response, if the secret was wiped by the janitor.
+	if secret.WipedAt != nil {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	// Get form values.
+	name := r.FormValue("name")
+	value := r.FormValue("value")
+	accessCode := r.FormValue("access_code")
+	recipientPublicKey := strings.TrimSpace(r.FormValue("recipient_public_key"))
+
+	// Check, if the form values are valid.
+	if err := helpers.ValidateEditSecretForm(name, value, isSecretValueEditable(&secret)); err != nil {
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(err),
+			messages.ErrFormDataNotValid,
+		)
+		return
+	}
+
+	// Re-encrypt the new value of the secret, if it is set.
+	secret.Name = name
+	if value != "" {
+		if err := a.encryptEditedSecret(&secret, value, accessCode, recipientPublicKey); err != nil {
+			// Wrap the error with template.
+			helpers.WrapHTTPError(
+				w, r, http.StatusBadRequest,
+				components.FormValidationError(
+					[]*messages.ErrorField{
+						{Name: "Encrypt secret", Message: err.Error()},
+					},
+				),
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Get the username of the current user.
+	editedBy := a.sessionUsername(r)
+
+	// Update the record by its key in the database.
+	if err := a.Database.QueryEditSecret(&secret, time.Now(), editedBy); err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Access code", Message: messages.ErrSecretAccessCodeNotValid},
+					{Name: "Edit secret", Message: err.Error()},
 				},
 			),
-			messages.ErrSecretAccessCodeNotValid,
+			err.Error(),
 		)
 		return
 	}
 
-	// Decrypt the secret value.
-	decryptedValue, err := helpers.DecryptString(a.Config.SecretKey, secret.Value)
+	// Log the edit of the secret.
+	slog.Info("secret edited", "key", key, "version", secret.Version, "is_value_edited", value != "", "edited_by", editedBy)
+
+	// Set the HX-Trigger header (to trigger a re-render by htmx).
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
+}
+
+// APIRollbackSecretByKeyHandler rolls back a secret name and value by its key to the given previous version (PATCH).
+// The rollback is saved as a new version of the secret, so the current version is kept too.
+func (a *Application) APIRollbackSecretByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Check, if the current URL has a 'key' parameter with a valid secret key.
+	if err := helpers.IsSecretKeyValid(key, 16); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check, if the current URL has a 'version' parameter with a valid version number.
+	version, err := strconv.Atoi(params.ByName("version"))
+	if err != nil || version < 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Get the secret by its key and its previous version from the database.
+	secret, err := a.Database.QueryGetSecretByKey(key)
 	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	secretVersion, err := a.Database.QueryGetSecretVersion(secret.ID, version)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Send a 409 conflict response, if the secret was wiped by the janitor.
+	if secret.WipedAt != nil {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	// Restore the name and encrypted fields of the previous version.
+	// The access code of the secret is kept, unless the data key is protected by the access code of the version.
+	secret.Name, secret.Value, secret.DataKey = secretVersion.Name, secretVersion.Value, secretVersion.DataKey
+	if secret.IsAccessCodeProtected {
+		secret.AccessCode = secretVersion.AccessCode
+	}
+
+	// Get the username of the current user.
+	rolledBackBy := a.sessionUsername(r)
+
+	// Update the record by its key in the database.
+	if err := a.Database.QueryEditSecret(&secret, time.Now(), rolledBackBy); err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Decrypt secret", Message: err.Error()},
+					{Name: "Roll back secret", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -263,15 +1047,16 @@ func (a *Application) APIUnlockSecretHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Set component options.
-	secret.Value = decryptedValue
+	// Log the rollback of the secret.
+	slog.Info("secret rolled back", "key", key, "version", secret.Version, "restored_version", version, "rolled_back_by", rolledBackBy)
 
-	// Render the secret page.
-	_ = pages.Secret(&secret, "unlocked").Render(r.Context(), w)
+	// Set the HX-Trigger header (to trigger a re-render by htmx).
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
 }
 
-// APIRenewSecretExpiresAtFieldByKeyHandler renews a secret 'expires_at' field by its key from the database (PATCH).
-func (a *Application) APIRenewSecretExpiresAtFieldByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+// APIResetSecretAttemptsByKeyHandler resets the failed access code attempts of a secret by its key,
+// and unlocks the secret, if it was locked after them (PATCH).
+func (a *Application) APIResetSecretAttemptsByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	// Get key from the URL.
 	key := params.ByName("key")
 
@@ -282,17 +1067,21 @@ func (a *Application) APIRenewSecretExpiresAtFieldByKeyHandler(w http.ResponseWr
 	}
 
 	// Patch the record by its key from the database.
-	if err := a.Database.QueryUpdateExpiresAtFieldByKey(key, time.Now().Add(time.Hour*24).Local()); err != nil {
+	if err := a.Database.QueryResetFailedAttemptsByKey(key); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	// Log the reset of the failed attempts.
+	slog.Info("secret failed attempts reset", "key", key, "reset_by", a.sessionUsername(r))
+
 	// Set the HX-Trigger header (to trigger a re-render by htmx).
-	w.Header().Set("HX-Trigger", "getActiveSecrets, getExpiredSecrets")
+	w.Header().Set("HX-Trigger", "getActiveSecrets")
 }
 
-// APIRestoreSecretAccessCodeFieldByKeyHandler restores a secret 'access_code' field by its key from the database (PATCH).
-func (a *Application) APIRestoreSecretAccessCodeFieldByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+// APIIssueSecretAccessCodeFieldByKeyHandler issues a new secret 'access_code' field by its key from the database (PATCH).
+// The old access code cannot be recovered, because only its hash is stored.
+func (a *Application) APIIssueSecretAccessCodeFieldByKeyHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	// Get key from the URL.
 	key := params.ByName("key")
 
@@ -309,18 +1098,37 @@ func (a *Application) APIRestoreSecretAccessCodeFieldByKeyHandler(w http.Respons
 		return
 	}
 
-	// Create a new hashed access code string with salt.
-	accessCodeHashed := helpers.HashString(8, fmt.Sprintf("%d", secret.CreatedAt.Unix()), a.Config.SecretKey)
+	// Check, if the secret is protected by the access code (the whole secret can only be re-issued).
+	if secret.IsAccessCodeProtected {
+		// Wrap the error with template.
+		helpers.WrapHTTPError(
+			w, r, http.StatusBadRequest,
+			components.FormValidationError(
+				[]*messages.ErrorField{
+					{Name: "Issue access code", Message: messages.ErrSecretAccessCodeNotReplaceable},
+				},
+			),
+			messages.ErrSecretAccessCodeNotReplaceable,
+		)
+		return
+	}
 
-	// Encrypt the access code value.
-	accessCodeEncrypted, err := helpers.EncryptString(a.Config.SecretKey, accessCodeHashed)
+	// Create a new random access code by the instance policy.
+	accessCode, err := helpers.GenerateAccessCode(a.Config.AccessCodePolicy)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Hash the access code value.
+	accessCodeHash, err := helpers.HashAccessCode(accessCode)
 	if err != nil {
 		// Wrap the error with template.
 		helpers.WrapHTTPError(
 			w, r, http.StatusBadRequest,
 			components.FormValidationError(
 				[]*messages.ErrorField{
-					{Name: "Encrypt access code", Message: err.Error()},
+					{Name: "Hash access code", Message: err.Error()},
 				},
 			),
 			err.Error(),
@@ -329,13 +1137,13 @@ func (a *Application) APIRestoreSecretAccessCodeFieldByKeyHandler(w http.Respons
 	}
 
 	// Patch the record by its key from the database.
-	if err := a.Database.QueryUpdateAccessCodeFieldByKey(key, accessCodeEncrypted); err != nil {
+	if err := a.Database.QueryUpdateAccessCodeFieldByKey(key, accessCodeHash); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	// Render the restore access code block.
-	_ = components.DashboardRestoreAccessCode(accessCodeHashed).Render(r.Context(), w)
+	// Render the new access code block.
+	_ = components.DashboardNewAccessCode(accessCode).Render(r.Context(), w)
 }
 
 // APIExpireSecretExpiresAtFieldByKeyHandler expires a secret 'expires_at' field by its key from the database (PATCH).
@@ -350,10 +1158,13 @@ func (a *Application) APIExpireSecretExpiresAtFieldByKeyHandler(w http.ResponseW
 	}
 
 	// Patch the record by its key from the database.
-	if err := a.Database.QueryUpdateExpiresAtFieldByKey(key, time.Now().Add(time.Second*1).Local()); err != nil {
+	if err := a.Database.QueryUpdateExpiresAtFieldByKey(key, time.Now().Local()); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+
+	// Set the HX-Trigger header (to trigger a re-render by htmx).
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
 }
 
 // APIDeleteSecretByKeyHandler deletes a secret by its key from the database (DELETE).
@@ -367,14 +1178,15 @@ func (a *Application) APIDeleteSecretByKeyHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Delete the record by its key from the database.
+	// Delete the record by its key from the database, and remove its files from disk.
 	if err := a.Database.QueryDeleteSecretByKey(key); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	a.sweepSecretFiles()
 
 	// Set the HX-Trigger header (to trigger a re-render by htmx).
-	w.Header().Set("HX-Trigger", "getActiveSecrets, getExpiredSecrets")
+	w.Header().Set("HX-Trigger", "getActiveSecrets, getScheduledSecrets, getExpiredSecrets")
 }
 
 // APIDashboardActiveSecretsHandler renders the active secrets block (GET).
@@ -390,6 +1202,46 @@ func (a *Application) APIDashboardActiveSecretsHandler(w http.ResponseWriter, r
 	_ = components.ActiveSecrets(secrets).Render(r.Context(), w)
 }
 
+// APIDashboardEditSecretHandler renders the edit secret dialog block with the previous versions of the secret (GET).
+func (a *Application) APIDashboardEditSecretHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Check, if the current URL has a 'key' parameter with a valid secret key.
+	if err := helpers.IsSecretKeyValid(key, 16); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Get the secret by its key and its previous versions from the database.
+	secret, err := a.Database.QueryGetSecretByKey(key)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	versions, err := a.Database.QueryGetSecretVersionsBySecretID(secret.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Render the edit secret dialog block.
+	_ = components.DashboardEditSecret(&secret, versions, isSecretValueEditable(&secret)).Render(r.Context(), w)
+}
+
+// APIDashboardScheduledSecretsHandler renders the scheduled secrets block (GET).
+func (a *Application) APIDashboardScheduledSecretsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Get all scheduled secrets.
+	secrets, err := a.Database.QueryGetScheduledSecrets()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Render the scheduled secrets block.
+	_ = components.ScheduledSecrets(secrets).Render(r.Context(), w)
+}
+
 // APIDashboardExpiredSecretsHandler renders the expired secrets block.
 func (a *Application) APIDashboardExpiredSecretsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Get all expired secrets.
@@ -403,6 +1255,57 @@ func (a *Application) APIDashboardExpiredSecretsHandler(w http.ResponseWriter, r
 	_ = components.ExpiredSecrets(secrets).Render(r.Context(), w)
 }
 
+// APIDashboardRenewSecretHandler renders the renew secret dialog block (GET).
+func (a *Application) APIDashboardRenewSecretHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key from the URL.
+	key := params.ByName("key")
+
+	// Check, if the current URL has a 'key' parameter with a valid secret key.
+	if err := helpers.IsSecretKeyValid(key, 16); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Get the secret by its key from the database.
+	secret, err := a.Database.QueryGetSecretByKey(key)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Render the renew secret dialog block.
+	_ = components.DashboardRenewSecret(
+		&secret, a.Config.SecretTTL.Min, a.Config.SecretTTL.Max, a.Config.SecretTTL.MaxLifetime,
+	).Render(r.Context(), w)
+}
+
+// APIDashboardRenewSecretsHandler renders the renew dialog block for the selected secrets (GET).
+func (a *Application) APIDashboardRenewSecretsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Get the keys of the selected secrets from the URL.
+	keys := uniqueBulkKeys(r.URL.Query()["keys"])
+
+	// Check, if the keys are valid.
+	if err := helpers.ValidateBulkSecretsForm(constants.ConstBulkActionRenew, keys); err != nil {
+		// Send a 400 bad request response.
+		w.WriteHeader(http.StatusBadRequest)
+
+		// Render the bulk action error.
+		_ = components.DashboardBulkSecretsError(err).Render(r.Context(), w)
+
+		return
+	}
+
+	// Get the selected secrets by their keys from the database.
+	secrets, err := a.Database.QueryGetSecretsByKeys(keys)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Render the renew secrets dialog block.
+	_ = components.DashboardBulkRenewSecrets(secrets, a.Config.SecretTTL.Min, a.Config.SecretTTL.Max).Render(r.Context(), w)
+}
+
 // APIUserLoginHandler logs in the user (POST).
 func (a *Application) APIUserLoginHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Parse the form data.
@@ -450,17 +1353,29 @@ func (a *Application) APIUserLoginHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Set session.
+	// Set session (the username is recorded as the author of the changes).
 	a.Session.Manager.Put(r.Context(), "authenticated", true)
+	a.Session.Manager.Put(r.Context(), "username", username)
 
 	// Redirect to the dashboard page.
 	w.Header().Set("HX-Redirect", "/dashboard")
 }
 
+// sessionUsername returns the username of the current user, which is recorded as the author of the changes.
+// The sessions created before the username was recorded have no one, so the master username is returned.
+func (a *Application) sessionUsername(r *http.Request) string {
+	if username := a.Session.Manager.GetString(r.Context(), "username"); username != "" {
+		return username
+	}
+
+	return a.Config.MasterUsername
+}
+
 // APIUserLogoutHandler logs out the user (GET).
 func (a *Application) APIUserLogoutHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Remove session.
 	a.Session.Manager.Remove(r.Context(), "authenticated")
+	a.Session.Manager.Remove(r.Context(), "username")
 
 	// Redirect to the index page.
 	w.Header().Set("HX-Redirect", "/")
@@ -1,9 +1,13 @@
 package application
 
 import (
+	"path/filepath"
+
 	"github.com/secretium/secretium/internal/attachments"
 	"github.com/secretium/secretium/internal/config"
+	"github.com/secretium/secretium/internal/constants"
 	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/keyring"
 	"github.com/secretium/secretium/internal/session"
 )
 
@@ -12,15 +16,25 @@ type Application struct {
 	Attachments *attachments.Attachments
 	Config      *config.Config
 	Database    *database.Database
+	Keyring     *keyring.Keyring
 	Session     *session.Session
+
+	// sharePool keeps the submitted shares of the split secrets in memory.
+	sharePool *sharePool
+
+	// secretFiles keeps the encrypted files of the secrets on disk.
+	secretFiles *fileStore
 }
 
 // New returns a new instance of Application.
-func New(a *attachments.Attachments, c *config.Config, d *database.Database, s *session.Session) *Application {
+func New(a *attachments.Attachments, c *config.Config, d *database.Database, k *keyring.Keyring, s *session.Session) *Application {
 	return &Application{
 		Attachments: a,
 		Config:      c,
 		Database:    d,
+		Keyring:     k,
 		Session:     s,
+		sharePool:   newSharePool(),
+		secretFiles: newFileStore(filepath.Join(constants.ConstConfigSQLitePath, constants.ConstSecretFilesFolder)),
 	}
 }
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+	"github.com/secretium/secretium/internal/templates/components"
+)
+
+// bulkAction is the bulk action for the selected secrets (the keys are unique) with the current datetime,
+// the new expiration datetime (for the renewal) and the username of the current user.
+type bulkAction struct {
+	Action         string
+	Keys           []string
+	Now, ExpiresAt time.Time
+	Username       string
+}
+
+// uniqueBulkKeys returns the non-empty keys of the selected secrets without duplicates (in the same order).
+func uniqueBulkKeys(values []string) (keys []string) {
+	for _, value := range values {
+		if key := strings.TrimSpace(value); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// runBulkAction runs the given bulk action for the selected secrets, and returns the result for each key in the same order.
+// The secrets, which are not found or cannot be processed by the action, are reported with the reason and skipped,
+// the other secrets are processed by a single batch query.
+func (a *Application) runBulkAction(b *bulkAction) ([]*components.BulkResult, error) {
+	// Purge all expired secrets (no keys are selected).
+	if b.Action == constants.ConstBulkActionPurgeExpired {
+		keys, err := a.Database.QueryDeleteSecretsExpiredBefore(b.Now)
+		if err != nil {
+			return nil, err
+		}
+		a.sweepSecretFiles()
+
+		// Log the purged secrets.
+		slog.Info("purged expired secrets", "mode", "bulk", "count", len(keys), "keys", strings.Join(keys, ","))
+
+		results := make([]*components.BulkResult, 0, len(keys))
+		for _, key := range keys {
+			results = append(results, &components.BulkResult{Key: key})
+		}
+
+		return results, nil
+	}
+
+	// Get the selected secrets by their keys from the database.
+	secrets, err := a.Database.QueryGetSecretsByKeys(b.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check, if each selected secret can be processed by the action.
+	results, pendingKeys := a.checkBulkAction(b, secrets)
+	if len(pendingKeys) == 0 {
+		return results, nil
+	}
+
+	// Run the batch query for the pending secrets.
+	var doneKeys []string
+	switch b.Action {
+	case constants.ConstBulkActionExpire:
+		doneKeys, err = a.Database.QueryExpireSecretsByKeys(pendingKeys, b.Now)
+	case constants.ConstBulkActionRenew:
+		doneKeys, err = a.Database.QueryRenewSecretsByKeys(pendingKeys, b.ExpiresAt, b.Now, b.Username)
+	case constants.ConstBulkActionDelete:
+		doneKeys, err = a.Database.QueryDeleteSecretsByKeys(pendingKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the files of the deleted secrets from disk.
+	if b.Action == constants.ConstBulkActionDelete {
+		a.sweepSecretFiles()
+	}
+
+	// Report the pending secrets, which were changed by another request since they were read.
+	for _, result := range results {
+		if result.Err == nil && !slices.Contains(doneKeys, result.Key) {
+			result.Err = database.ErrSecretIsChanged
+		}
+	}
+
+	// Log the bulk action.
+	slog.Info(
+		"bulk action done",
+		"action", b.Action,
+		"count", len(doneKeys),
+		"keys", strings.Join(doneKeys, ","),
+		"by", b.Username,
+	)
+
+	return results, nil
+}
+
+// checkBulkAction returns the results for the selected secrets, where the secrets, which cannot be processed
+// by the action, have the reason error, and the keys of the other (pending) secrets.
+func (a *Application) checkBulkAction(b *bulkAction, secrets []*database.Secret) (results []*components.BulkResult, pendingKeys []string) {
+	for _, key := range b.Keys {
+		result := &components.BulkResult{Key: key}
+		results = append(results, result)
+
+		// Check, if the key is valid.
+		if err := helpers.IsSecretKeyValid(key, constants.ConstSecretKeyLength); err != nil {
+			result.Err = err
+			continue
+		}
+
+		// Check, if the secret is found.
+		i := slices.IndexFunc(secrets, func(s *database.Secret) bool { return s.Key == key })
+		if i < 0 {
+			result.Err = errors.New(messages.ErrSecretKeyEmptyOrNotFound)
+			continue
+		}
+		secret := secrets[i]
+		result.Name = secret.Name
+
+		// Check, if the secret can be processed by the action.
+		switch b.Action {
+		case constants.ConstBulkActionExpire:
+			if !secret.ExpiresAt.After(b.Now) {
+				result.Err = errors.New(messages.ErrSecretIsAlreadyExpired)
+				continue
+			}
+		case constants.ConstBulkActionRenew:
+			if secret.WipedAt != nil {
+				result.Err = database.ErrSecretIsWiped
+				continue
+			}
+
+			// Check, if the total lifetime of the secret is not exceeded.
+			if err := helpers.CheckSecretLifetime(secret.CreatedAt, b.ExpiresAt, a.Config.SecretTTL.MaxLifetime); err != nil {
+				result.Err = err
+				continue
+			}
+		}
+
+		pendingKeys = append(pendingKeys, key)
+	}
+
+	return results, pendingKeys
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/helpers"
+)
+
+// countFailedAttempt counts the failed access code attempt of the secret by its key, if the given unlock error is
+// caused by a wrong access code. Depending on the failed attempts action, the secret is locked or destroyed, when
+// the limit of the failed attempts is reached. Returns ErrSecretIsLocked or ErrSecretIsDestroyed, if the secret
+// was locked or destroyed by this attempt, otherwise the given error.
+func (a *Application) countFailedAttempt(key string, err error) error {
+	// Check, if the access code is wrong.
+	if !errors.Is(err, helpers.ErrAccessCodeNotValid) {
+		return err
+	}
+
+	// Count the failed attempt of the secret.
+	attempts, failErr := a.Database.QueryFailAttemptByKey(
+		key, a.Config.FailedAttempts.Limit,
+		a.Config.FailedAttempts.Action == constants.ConstFailedAttemptsActionDestroy,
+		time.Now().Local(),
+	)
+	switch {
+	case errors.Is(failErr, database.ErrSecretIsLocked), errors.Is(failErr, database.ErrSecretIsDestroyed):
+		// Log the locked or destroyed secret.
+		slog.Info(failErr.Error(), "key", key, "failed_attempts", attempts, "action", a.Config.FailedAttempts.Action)
+
+		// Remove the files of the destroyed secret from disk.
+		if errors.Is(failErr, database.ErrSecretIsDestroyed) {
+			a.sweepSecretFiles()
+		}
+
+		return failErr
+	case failErr != nil:
+		slog.Error("failed to count failed attempt", "key", key, "details", failErr.Error())
+	}
+
+	return err
+}
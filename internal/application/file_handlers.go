@@ -0,0 +1,77 @@
+package application
+
+import (
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// APIDownloadSecretFileHandler decrypts the file of the unlocked secret, and sends it as an attachment (GET).
+// The file can only be downloaded in the same browser, where the secret was unlocked, for the download time.
+func (a *Application) APIDownloadSecretFileHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// Get key and file ID from the URL.
+	key := params.ByName("key")
+	id, err := strconv.Atoi(params.ByName("id"))
+
+	// Check, if the current URL has a valid secret key and file ID.
+	if err != nil || helpers.IsSecretKeyValid(key, constants.ConstSecretKeyLength) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Do not cache or index the file.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	// Get the key of the file from the download grant in the session.
+	fileKey, err := a.secretFileGrant(r, key, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	// Get the file by the secret key and its ID from the database (the files of the expired or wiped secrets are skipped).
+	file, err := a.Database.QueryGetSecretFile(key, id, time.Now().Local())
+	if err != nil {
+		http.Error(w, messages.ErrSecretKeyEmptyOrNotFound, http.StatusNotFound)
+		return
+	}
+
+	// Open the encrypted file on disk.
+	src, err := a.secretFiles.open(file.StorageKey)
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, messages.ErrSecretKeyEmptyOrNotFound, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = src.Close() }()
+
+	// Give more time to download the file.
+	a.extendTransferDeadlines(w)
+
+	// Set the headers of the file attachment.
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// Decrypt the file to the response.
+	if _, err := helpers.DecryptStream(fileKey, w, src); err != nil {
+		slog.Error("failed to download secret file", "key", key, "file_id", id, "details", err.Error())
+
+		// Abort the response, so the client does not keep the incomplete file.
+		panic(http.ErrAbortHandler)
+	}
+}
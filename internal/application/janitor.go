@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/secretium/secretium/internal/constants"
+)
+
+// runJanitor purges the secrets, which expired longer than the retention period ago, right away and then
+// after every interval, until the given context is cancelled. Depending on the janitor mode, the secrets
+// are permanently deleted, or only their encrypted fields are wiped (the metadata is kept for the dashboard).
+func (a *Application) runJanitor(ctx context.Context) {
+	// Log the start of the janitor.
+	slog.Info(
+		"running janitor",
+		"mode", a.Config.Janitor.Mode,
+		"retention_period", a.Config.Janitor.RetentionPeriod.String(),
+		"interval", a.Config.Janitor.Interval.String(),
+	)
+
+	// Create a ticker with the janitor interval.
+	ticker := time.NewTicker(a.Config.Janitor.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Purge the expired secrets, and remove their files from disk.
+		a.purgeExpiredSecrets(time.Now().Local())
+		a.sweepSecretFiles()
+
+		// Wait for the next tick or the end of the application.
+		select {
+		case <-ctx.Done():
+			slog.Info("janitor gracefully stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeExpiredSecrets deletes or wipes the secrets, which expired longer than the retention period before the given date.
+func (a *Application) purgeExpiredSecrets(now time.Time) {
+	// Get the date, before which the expired secrets are purged.
+	before := now.Add(-a.Config.Janitor.RetentionPeriod)
+
+	var keys []string
+	var err error
+	switch a.Config.Janitor.Mode {
+	case constants.ConstJanitorModeDelete:
+		keys, err = a.Database.QueryDeleteSecretsExpiredBefore(before)
+	case constants.ConstJanitorModeWipe:
+		keys, err = a.Database.QueryWipeSecretsExpiredBefore(before, now)
+	default:
+		return
+	}
+	if err != nil {
+		slog.Error("failed to purge expired secrets", "mode", a.Config.Janitor.Mode, "details", err.Error())
+		return
+	}
+
+	// Log the purged secrets.
+	if len(keys) > 0 {
+		slog.Info(
+			"purged expired secrets",
+			"mode", a.Config.Janitor.Mode,
+			"expired_before", before.Format(time.RFC3339),
+			"count", len(keys),
+			"keys", strings.Join(keys, ","),
+		)
+	}
+}
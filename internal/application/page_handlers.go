@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/secretium/secretium/internal/database"
 	"github.com/secretium/secretium/internal/helpers"
 	"github.com/secretium/secretium/internal/templates"
 	"github.com/secretium/secretium/internal/templates/pages"
@@ -61,9 +62,31 @@ func (a *Application) PageSecretHandler(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	// Ask the search engines not to index the secret page.
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	// Check, if the page is requested by the link unfurler (like chat or social media bot).
+	// The unfurlers get a neutral page without any details of the secret, so the link preview reveals nothing.
+	if helpers.IsUnfurlerUserAgent(r.UserAgent()) {
+		// Set the template options.
+		templateOptions.PageTitle = "Shared secret"
+		templateOptions.Component = pages.Secret(&database.Secret{}, "preview")
+
+		// Render the neutral secret page.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
 	// Get secret by its key from the database.
 	secret, err := a.Database.QueryGetSecretByKey(key)
 	if err != nil {
+		// Check, if the key is a share key of the split secret.
+		if share, err := a.Database.QueryGetSecretShareByKey(key); err == nil {
+			a.renderSecretSharePage(w, r, templateOptions, &share)
+			return
+		}
+
 		// Set the key to the secret, because the secret is not found (Secret struct has zero values).
 		secret.Key = key
 
@@ -91,13 +114,117 @@ func (a *Application) PageSecretHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	// Check, if the secret is not available yet.
+	if secret.AvailableAt.After(time.Now()) {
+		// Set the template options.
+		templateOptions.PageTitle = "Secret is not available yet"
+		templateOptions.Component = pages.Secret(&secret, "scheduled")
+
+		// Render the secret page.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Check, if the secret is locked after too many failed access code attempts.
+	if secret.LockedAt != nil {
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is locked"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "locked-out")
+
+		// Render the secret page.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Issue a new reveal token for the unlock form of the secret page.
+	revealToken, err := a.issueRevealToken(r, secret.Key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	// Set the template options.
-	templateOptions.Component = pages.Secret(&secret, "locked")
+	templateOptions.Component = pages.SecretLocked(&secret, revealToken)
 
 	// Render the secret page.
 	_ = templates.Layout(templateOptions).Render(r.Context(), w)
 }
 
+// renderSecretSharePage renders the secret page for the given share of the split secret.
+func (a *Application) renderSecretSharePage(w http.ResponseWriter, r *http.Request, templateOptions *templates.TemplateOptions, share *database.SecretShare) {
+	// Get the split secret of the share by its ID from the database.
+	secret, err := a.Database.QueryGetSecretByID(share.SecretID)
+	if err != nil {
+		// Set the key to the secret, because the secret is not found (Secret struct has zero values).
+		secret.Key = share.Key
+
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is not found"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "not-found")
+
+		// Render the secret page with 404 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Check, if the secret is expired.
+	if !helpers.DatetimeChecker(secret.ExpiresAt.Local(), time.Now().Local()) {
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is expired"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "expired")
+
+		// Render the secret page with 400 error.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Check, if the secret is not available yet.
+	if secret.AvailableAt.After(time.Now()) {
+		// Set the template options.
+		templateOptions.PageTitle = "Secret is not available yet"
+		templateOptions.Component = pages.Secret(&secret, "scheduled")
+
+		// Render the secret page.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Check, if the secret is locked after too many failed access code attempts.
+	if secret.LockedAt != nil {
+		// Set the template options.
+		templateOptions.PageTitle = "Oops... Secret is locked"
+		templateOptions.LogoVariant = "error"
+		templateOptions.Component = pages.Secret(&secret, "locked-out")
+
+		// Render the secret page.
+		_ = templates.Layout(templateOptions).Render(r.Context(), w)
+
+		return
+	}
+
+	// Issue a new reveal token for the unlock form of the share page.
+	revealToken, err := a.issueRevealToken(r, share.Key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Set the template options.
+	templateOptions.PageTitle = "Unlock your share of the secret"
+	templateOptions.Component = pages.SecretShare(&secret, share, "locked", 0, revealToken)
+
+	// Render the secret share page.
+	_ = templates.Layout(templateOptions).Render(r.Context(), w)
+}
+
 // PageDashboardIndexHandler renders the dashboard index page (GET).
 func (a *Application) PageDashboardIndexHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// Create template options.
@@ -125,6 +252,28 @@ func (a *Application) PageDashboardIndexHandler(w http.ResponseWriter, r *http.R
 
 // PageDashboardAddSecretHandler renders the add secret page (GET).
 func (a *Application) PageDashboardAddSecretHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// Set component options.
+	componentOptions := &templates.DashboardComponentOptions{
+		State:            "add-secret",
+		AccessCodePolicy: a.Config.AccessCodePolicy,
+		MinTTL:           a.Config.SecretTTL.Min,
+		MaxTTL:           a.Config.SecretTTL.Max,
+		FilesMaxSize:     a.Config.SecretFiles.MaxSize,
+	}
+
+	// Check, if the URL has a 'reissue' parameter with a valid secret key.
+	if reissueKey := r.URL.Query().Get("reissue"); helpers.IsSecretKeyValid(reissueKey, 16) == nil {
+		// Get the re-issued secret by its key from the database.
+		secret, err := a.Database.QueryGetSecretByKey(reissueKey)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Set the re-issued secret to fill the form.
+		componentOptions.Secret = &secret
+	}
+
 	// Create template options.
 	templateOptions := &templates.TemplateOptions{
 		PageTitle: "Add secret",
@@ -137,11 +286,7 @@ func (a *Application) PageDashboardAddSecretHandler(w http.ResponseWriter, r *ht
 		Footer: &templates.ElementStyle{
 			CSSClass: "dashboard",
 		},
-		Component: pages.Dashboard(
-			&templates.DashboardComponentOptions{
-				State: "add-secret",
-			},
-		),
+		Component: pages.Dashboard(componentOptions),
 	}
 
 	// Render the dashboard add secret page.
@@ -180,8 +325,30 @@ func (a *Application) PageDashboardShareSecretHandler(w http.ResponseWriter, r *
 		Secret:   &secret,
 	}
 
-	// Check, if the URL has an 'access_code' parameter.
-	if r.URL.Query() != nil && r.URL.Query().Get("access_code") != "" {
+	// Check, if the secret is split into shares.
+	if secret.SharesTotal > 0 {
+		// Get the shares of the secret from the database.
+		shares, err := a.Database.QueryGetSecretSharesBySecretID(secret.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Set the share URLs with the access codes (the access codes are only in the URL after the secret is added).
+		accessCodes := r.URL.Query()["access_code"]
+		for i, share := range shares {
+			shareURL.Path = fmt.Sprintf("get/%s", share.Key)
+			dashboardShare := &templates.DashboardSecretShare{
+				Number:   share.Number,
+				ShareURL: shareURL.String(),
+			}
+			if i < len(accessCodes) {
+				dashboardShare.AccessCode = accessCodes[i]
+			}
+			componentOptions.Shares = append(componentOptions.Shares, dashboardShare)
+		}
+	} else if r.URL.Query() != nil && r.URL.Query().Get("access_code") != "" {
+		// Set the access code, if the URL has an 'access_code' parameter.
 		componentOptions.Data = map[string]string{
 			"AccessCode": r.URL.Query().Get("access_code"),
 		}
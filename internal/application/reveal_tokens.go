@@ -0,0 +1,50 @@
+package application
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// revealTokenSessionKey returns the session key of the reveal token of the secret page by the secret (or share) key.
+func revealTokenSessionKey(key string) string {
+	return "reveal_token:" + key
+}
+
+// issueRevealToken generates a new reveal token for the secret page by the secret (or share) key and keeps it
+// in the session of the visitor. The token is sent back by the unlock form, so the secret can only be unlocked
+// by the explicit action on its page in the same browser (not by the link unfurlers, which only fetch the page).
+func (a *Application) issueRevealToken(r *http.Request, key string) (string, error) {
+	// Generate a new random token.
+	token, err := helpers.GenerateRandomString(constants.ConstSecretRevealTokenLength)
+	if err != nil {
+		return "", err
+	}
+
+	// Keep the token in the session.
+	a.Session.Manager.Put(r.Context(), revealTokenSessionKey(key), token)
+
+	return token, nil
+}
+
+// verifyRevealToken returns nil, if the given reveal token matches the one issued for the secret page in the session.
+func (a *Application) verifyRevealToken(r *http.Request, key, token string) error {
+	// Get the issued token from the session.
+	issuedToken := a.Session.Manager.GetString(r.Context(), revealTokenSessionKey(key))
+
+	// Compare the tokens in constant time.
+	if issuedToken == "" || subtle.ConstantTimeCompare([]byte(issuedToken), []byte(token)) != 1 {
+		return errors.New(messages.ErrSecretRevealTokenNotValid)
+	}
+
+	return nil
+}
+
+// revokeRevealToken removes the reveal token of the secret page from the session, after the secret (or share) is unlocked.
+func (a *Application) revokeRevealToken(r *http.Request, key string) {
+	a.Session.Manager.Remove(r.Context(), revealTokenSessionKey(key))
+}
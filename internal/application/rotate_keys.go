@@ -0,0 +1,104 @@
+package application
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/database"
+)
+
+// RotateKeys re-wraps the data keys and re-encrypts the access codes of all secrets (with their versions and files) in the database
+// with the active key in batches. The secrets already protected by the active key are skipped,
+// so the rotation can be safely resumed by running it again after an interruption.
+func (a *Application) RotateKeys() error {
+	// Create a new DB schema, if it does not exist, and apply the migrations.
+	if err := a.migrate(); err != nil {
+		return err
+	}
+
+	// Log the start of the key rotation.
+	slog.Info("rotating keys", "active_key_id", a.Keyring.Active().ID(), "keys", len(a.Keyring.Keys))
+
+	// Rotate the secrets.
+	rotated, skipped, err := a.rotateKeysInBatches("secret", a.Database.QueryGetSecretsAfterID, a.Database.QueryRotateEncryptedFields)
+	if err != nil {
+		return err
+	}
+
+	// Rotate the previous versions of the secrets (they are restored without re-encryption on the rollback).
+	rotatedVersions, skippedVersions, err := a.rotateKeysInBatches(
+		"version", a.Database.QueryGetSecretVersionsAfterID, a.Database.QueryRotateVersionEncryptedFields,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Rotate the files of the secrets (only their data keys are re-wrapped, the encrypted files stay unchanged).
+	rotatedFiles, skippedFiles, err := a.rotateKeysInBatches(
+		"file", a.Database.QueryGetSecretFilesAfterID, a.Database.QueryRotateFileEncryptedFields,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Log the end of the key rotation.
+	slog.Info(
+		"keys rotated",
+		"rotated", rotated, "skipped", skipped,
+		"rotated_versions", rotatedVersions, "skipped_versions", skippedVersions,
+		"rotated_files", rotatedFiles, "skipped_files", skippedFiles,
+	)
+
+	return nil
+}
+
+// rotateKeysInBatches re-encrypts the records, which are got by the given function in batches, with the active key,
+// and updates them by the given function. Returns the number of the rotated and skipped records.
+func (a *Application) rotateKeysInBatches(
+	name string,
+	get func(id, limit int) ([]*database.Secret, error),
+	update func(rotations []*database.SecretRotation) (int64, error),
+) (rotated, skipped int64, err error) {
+	var lastID int
+	for {
+		// Get the next batch of records.
+		secrets, err := get(lastID, constants.ConstEncryptionRotationBatchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// Stop, if there are no more records.
+		if len(secrets) == 0 {
+			break
+		}
+
+		// Re-encrypt the records, which are not protected by the active key.
+		rotations := make([]*database.SecretRotation, 0, len(secrets))
+		for _, secret := range secrets {
+			if !a.isSecretOutdated(secret) {
+				continue
+			}
+
+			rotation, err := a.reencryptSecret(secret)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to rotate %s #%d: %w", name, secret.ID, err)
+			}
+			rotations = append(rotations, rotation)
+		}
+
+		// Update the re-encrypted records in the database.
+		updated, err := update(rotations)
+		if err != nil {
+			return 0, 0, err
+		}
+		rotated += updated
+		skipped += int64(len(secrets)) - updated
+		lastID = secrets[len(secrets)-1].ID
+
+		// Log the progress of the key rotation.
+		slog.Info("rotated batch of "+name+"s", "last_id", lastID, "rotated", rotated, "skipped", skipped)
+	}
+
+	return rotated, skipped, nil
+}
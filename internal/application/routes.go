@@ -22,9 +22,10 @@ func (a *Application) router() *httprouter.Router {
 	router.GET("/get/:key", a.PageSecretHandler) // handle the secret page
 
 	// Add a public set of API handlers.
-	router.POST("/api/secret/unlock/:key", a.MiddlewareHTMXRequest(a.APIUnlockSecretHandler))                     // handle the unlock secret request to the API
-	router.PATCH("/api/secret/expire/:key", a.MiddlewareHTMXRequest(a.APIExpireSecretExpiresAtFieldByKeyHandler)) // handle the expire secret request to the API
-	router.POST("/api/user/login", a.MiddlewareHTMXRequest(a.APIUserLoginHandler))                                // handle the user login request to the API
+	router.POST("/api/secret/unlock/:key", a.MiddlewareHTMXRequest(a.APIUnlockSecretHandler))            // handle the unlock secret request to the API
+	router.POST("/api/secret/unlock-share/:key", a.MiddlewareHTMXRequest(a.APIUnlockSecretShareHandler)) // handle the unlock secret share request to the API
+	router.POST("/api/user/login", a.MiddlewareHTMXRequest(a.APIUserLoginHandler))                       // handle the user login request to the API
+	router.GET("/api/secret/file/:key/:id", a.APIDownloadSecretFileHandler)                              // handle the download secret file request to the API
 
 	/*
 		Private routes.
@@ -36,13 +37,22 @@ func (a *Application) router() *httprouter.Router {
 	router.GET("/dashboard/share/:key", a.MiddlewareUserAuthWithHTMXRequest(a.PageDashboardShareSecretHandler)) // handle the dashboard share secret page
 
 	// Add a set of API handlers.
-	router.POST("/api/secret/add", a.MiddlewareUserAuthWithHTMXRequest(a.APIAddSecretHandler))                                   // handle the add secret request to the API
-	router.PATCH("/api/secret/renew/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIRenewSecretExpiresAtFieldByKeyHandler))      // handle the renew secret request to the API
-	router.PATCH("/api/secret/restore/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIRestoreSecretAccessCodeFieldByKeyHandler)) // handle the restore secret access code request to the API
-	router.DELETE("/api/secret/delete/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIDeleteSecretByKeyHandler))                 // handle the delete secret request to the API
-	router.GET("/api/dashboard/secrets/active", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardActiveSecretsHandler))         // handle the get active secret request to the API
-	router.GET("/api/dashboard/secrets/expired", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardExpiredSecretsHandler))       // handle the get expired secret request to the API
-	router.GET("/api/user/logout", a.MiddlewareUserAuthWithHTMXRequest(a.APIUserLogoutHandler))                                  // handle the user logout request to the API
+	router.POST("/api/secret/add", a.MiddlewareUserAuthWithHTMXRequest(a.APIAddSecretHandler))                                     // handle the add secret request to the API
+	router.PATCH("/api/secret/renew/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIRenewSecretExpiresAtFieldByKeyHandler))        // handle the renew secret request to the API
+	router.PATCH("/api/secret/edit/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIEditSecretByKeyHandler))                        // handle the edit secret request to the API
+	router.PATCH("/api/secret/rollback/:key/:version", a.MiddlewareUserAuthWithHTMXRequest(a.APIRollbackSecretByKeyHandler))       // handle the roll back secret request to the API
+	router.PATCH("/api/secret/attempts/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIResetSecretAttemptsByKeyHandler))           // handle the reset secret failed attempts request to the API
+	router.PATCH("/api/secret/expire/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIExpireSecretExpiresAtFieldByKeyHandler))      // handle the expire secret request to the API
+	router.PATCH("/api/secret/access-code/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIIssueSecretAccessCodeFieldByKeyHandler)) // handle the issue new secret access code request to the API
+	router.POST("/api/secret/bulk", a.MiddlewareUserAuthWithHTMXRequest(a.APIBulkSecretsHandler))                                  // handle the bulk secrets action request to the API
+	router.DELETE("/api/secret/delete/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIDeleteSecretByKeyHandler))                   // handle the delete secret request to the API
+	router.GET("/api/dashboard/secrets/active", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardActiveSecretsHandler))           // handle the get active secret request to the API
+	router.GET("/api/dashboard/secrets/expired", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardExpiredSecretsHandler))         // handle the get expired secret request to the API
+	router.GET("/api/dashboard/secrets/scheduled", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardScheduledSecretsHandler))     // handle the get scheduled secret request to the API
+	router.GET("/api/dashboard/secrets/renew/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardRenewSecretHandler))         // handle the get renew secret dialog request to the API
+	router.GET("/api/dashboard/secrets/renew-many", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardRenewSecretsHandler))        // handle the get renew selected secrets dialog request to the API
+	router.GET("/api/dashboard/secrets/edit/:key", a.MiddlewareUserAuthWithHTMXRequest(a.APIDashboardEditSecretHandler))           // handle the get edit secret dialog request to the API
+	router.GET("/api/user/logout", a.MiddlewareUserAuthWithHTMXRequest(a.APIUserLogoutHandler))                                    // handle the user logout request to the API
 
 	// Add a set of QR code generation handler.
 	router.GET("/qr/generate/:key", a.MiddlewareUserAuth(a.QRCodeGenerationHandler)) // handle the request to generate a QR code
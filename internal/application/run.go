@@ -8,16 +8,22 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
+
+	"github.com/secretium/secretium/internal/constants"
 )
 
 // Run runs the application.
 func (a *Application) Run() error {
-	// Create a new DB schema, if it does not exist.
-	if err := a.Database.Migrate("sql_queries/init.sql"); err != nil {
+	// Create a new DB schema, if it does not exist, and apply the migrations.
+	if err := a.migrate(); err != nil {
 		return err
 	}
 
+	// Remove the files of the deleted secrets from disk, which were left after the last run.
+	a.sweepSecretFiles()
+
 	// Create a new server instance with options from environment variables.
 	// For more information, see https://blog.cloudflare.com/the-complete-guide-to-golang-net-http-timeouts/
 	server := &http.Server{
@@ -49,6 +55,18 @@ func (a *Application) Run() error {
 		}
 	}()
 
+	// Start the janitor in a separate goroutine, if it is enabled.
+	janitorCtx, stopJanitor := context.WithCancel(context.Background())
+	defer stopJanitor()
+	var janitor sync.WaitGroup
+	if a.Config.Janitor.Mode != constants.ConstJanitorModeOff {
+		janitor.Add(1)
+		go func() {
+			defer janitor.Done()
+			a.runJanitor(janitorCtx)
+		}()
+	}
+
 	// Create a channel to listen for OS signals.
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
@@ -67,5 +85,20 @@ func (a *Application) Run() error {
 
 	slog.Info("server gracefully stopped")
 
+	// Stop the janitor and wait for the current purge to finish (before the DB connection is closed).
+	stopJanitor()
+	janitor.Wait()
+
 	return nil
 }
+
+// migrate creates a new DB schema, if it does not exist, and applies the migrations.
+func (a *Application) migrate() error {
+	// Create a new DB schema, if it does not exist.
+	if err := a.Database.Migrate("sql_queries/init.sql"); err != nil {
+		return err
+	}
+
+	// Apply the migrations, which are newer than the DB schema version.
+	return a.Database.MigrateVersions("sql_queries/migrations")
+}
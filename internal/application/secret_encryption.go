@@ -0,0 +1,417 @@
+package application
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// encryptSecretValue encrypts the given secret value with a new random data key.
+// It returns the encrypted value and the data key wrapped by the active key of the keyring.
+func (a *Application) encryptSecretValue(value string) (valueEncrypted, dataKeyWrapped string, err error) {
+	// Generate a new data key for the secret.
+	dataKey, err := helpers.GenerateDataKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Encrypt the secret value with the data key.
+	valueEncrypted, err = helpers.EncryptString(dataKey, value)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Wrap the data key with the active key.
+	dataKeyWrapped, err = a.Keyring.WrapKey(dataKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return valueEncrypted, dataKeyWrapped, nil
+}
+
+// decryptSecretValue decrypts the value of the given secret with its data key.
+// The secrets without a data key (created before the envelope encryption) are decrypted with the keyring directly.
+func (a *Application) decryptSecretValue(secret *database.Secret) (string, error) {
+	// Check, if the secret has a data key.
+	if secret.DataKey == "" {
+		return a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeValue, secret.Value)
+	}
+
+	// Unwrap the data key of the secret.
+	dataKey, err := a.Keyring.UnwrapKey(secret.DataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return helpers.DecryptString(dataKey, secret.Value)
+}
+
+// isSecretOutdated returns true if the encrypted fields of the given secret are not protected by the active key,
+// or its access code is not hashed yet (stored encrypted before the access code hashing).
+func (a *Application) isSecretOutdated(secret *database.Secret) bool {
+	// Check, if the secret is split (its data key exists only in the shares, protected by their access codes).
+	if secret.SharesTotal > 0 {
+		return false
+	}
+
+	// Check, if the secret is protected by the access code (its access code is always a hash).
+	if secret.IsAccessCodeProtected {
+		return a.Keyring.IsOutdated(secret.DataKey)
+	}
+
+	return secret.DataKey == "" || a.Keyring.IsOutdated(secret.DataKey) || !helpers.IsAccessCodeHash(secret.AccessCode)
+}
+
+// reencryptSecret re-encrypts the encrypted fields of the given secret with the active key of the keyring.
+// The encrypted access codes (stored before the access code hashing) are replaced with their hashes.
+// For the secrets with a data key, only the data key is re-wrapped and the encrypted value stays unchanged.
+// For the secrets protected by the access code, only the outer (keyring) layer of the data key is re-wrapped.
+func (a *Application) reencryptSecret(secret *database.Secret) (*database.SecretRotation, error) {
+	rotation := &database.SecretRotation{
+		Secret: secret,
+		Value:  secret.Value,
+	}
+
+	// Check, if the secret is protected by the access code.
+	if secret.IsAccessCodeProtected {
+		// Unwrap the protected data key and wrap it with the active key.
+		dataKeyProtected, err := a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, secret.DataKey)
+		if err != nil {
+			return nil, err
+		}
+		rotation.DataKey, err = a.Keyring.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, dataKeyProtected)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the access code hash unchanged.
+		rotation.AccessCode = secret.AccessCode
+
+		return rotation, nil
+	}
+
+	// Keep the access code hash unchanged, or replace the encrypted access code with its hash.
+	rotation.AccessCode = secret.AccessCode
+	if !helpers.IsAccessCodeHash(secret.AccessCode) {
+		accessCode, err := a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeAccessCode, secret.AccessCode)
+		if err != nil {
+			return nil, err
+		}
+		rotation.AccessCode, err = helpers.HashAccessCode(accessCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check, if the secret has a data key.
+	if secret.DataKey == "" {
+		// Decrypt the secret value and encrypt it with a new data key.
+		value, err := a.decryptSecretValue(secret)
+		if err != nil {
+			return nil, err
+		}
+		rotation.Value, rotation.DataKey, err = a.encryptSecretValue(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return rotation, nil
+	}
+
+	// Unwrap the data key and wrap it with the active key.
+	dataKey, err := a.Keyring.UnwrapKey(secret.DataKey)
+	if err != nil {
+		return nil, err
+	}
+	rotation.DataKey, err = a.Keyring.WrapKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+// encryptProtectedSecretValue encrypts the given secret value with a new random data key, which is protected by the access code.
+// The data key is wrapped by a key derived from the access code with Argon2id, and then wrapped again by the active key
+// of the keyring, which works as a server pepper. It returns the encrypted value, the wrapped data key and the access
+// code hash, which is stored instead of the encrypted access code.
+func (a *Application) encryptProtectedSecretValue(value, accessCode string) (valueEncrypted, dataKeyWrapped, accessCodeHash string, err error) {
+	// Generate a new data key for the secret.
+	dataKey, err := helpers.GenerateDataKey()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Encrypt the secret value with the data key.
+	valueEncrypted, err = helpers.EncryptString(dataKey, value)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Derive a new key from the access code.
+	accessCodeKey, accessCodeHash, err := helpers.DeriveAccessCodeKey(accessCode)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Wrap the data key with the access code key.
+	dataKeyProtected, err := helpers.EncryptString(accessCodeKey, base64.RawStdEncoding.EncodeToString(dataKey))
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Wrap the protected data key with the active key.
+	dataKeyWrapped, err = a.Keyring.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, dataKeyProtected)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return valueEncrypted, dataKeyWrapped, accessCodeHash, nil
+}
+
+// decryptProtectedSecretValue decrypts the value of the given secret, which is protected by the access code.
+// It returns an error, if the given access code does not match the access code hash of the secret.
+func (a *Application) decryptProtectedSecretValue(secret *database.Secret, accessCode string) (string, error) {
+	// Verify the access code and derive the access code key.
+	accessCodeKey, err := helpers.VerifyAccessCodeKey(accessCode, secret.AccessCode)
+	if err != nil {
+		return "", err
+	}
+
+	// Unwrap the protected data key with the keyring.
+	dataKeyProtected, err := a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, secret.DataKey)
+	if err != nil {
+		return "", err
+	}
+
+	// Unwrap the data key with the access code key.
+	dataKeyEncoded, err := helpers.DecryptString(accessCodeKey, dataKeyProtected)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := base64.RawStdEncoding.DecodeString(dataKeyEncoded)
+	if err != nil {
+		return "", err
+	}
+
+	return helpers.DecryptString(dataKey, secret.Value)
+}
+
+// encryptNewSecret creates a new random key of the given new secret, and encrypts the given value.
+// The custom access code (set by the creator) is used, if it is not empty, otherwise a new random
+// access code is created by the given policy. It returns the access code to share.
+func (a *Application) encryptNewSecret(secret *database.Secret, value, customAccessCode string, policy *helpers.AccessCodePolicy) (accessCode string, err error) {
+	// Create a new random access code, if the custom access code is not set.
+	accessCode = customAccessCode
+	if accessCode == "" {
+		accessCode, err = helpers.GenerateAccessCode(policy)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Create a new random key.
+	secret.Key, err = helpers.GenerateRandomString(constants.ConstSecretKeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	// Check, if the secret is protected by the access code.
+	if secret.IsAccessCodeProtected {
+		// Encrypt the secret value with a new data key protected by the access code.
+		secret.Value, secret.DataKey, secret.AccessCode, err = a.encryptProtectedSecretValue(value, accessCode)
+		if err != nil {
+			return "", err
+		}
+
+		return accessCode, nil
+	}
+
+	// Encrypt the secret value with a new data key.
+	secret.Value, secret.DataKey, err = a.encryptSecretValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	// Hash the access code value.
+	secret.AccessCode, err = helpers.HashAccessCode(accessCode)
+	if err != nil {
+		return "", err
+	}
+
+	return accessCode, nil
+}
+
+// verifySecretAccessCode checks the given access code against the access code of the given secret in constant time.
+// The encrypted access codes (stored before the access code hashing) are decrypted with the keyring to compare.
+func (a *Application) verifySecretAccessCode(secret *database.Secret, accessCode string) error {
+	// Check, if the access code of the secret is hashed.
+	if helpers.IsAccessCodeHash(secret.AccessCode) {
+		return helpers.VerifyAccessCodeHash(accessCode, secret.AccessCode)
+	}
+
+	// Decrypt the access code value.
+	accessCodeDecrypted, err := a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeAccessCode, secret.AccessCode)
+	if err != nil {
+		return err
+	}
+
+	// Compare the access codes in constant time.
+	if subtle.ConstantTimeCompare([]byte(accessCode), []byte(accessCodeDecrypted)) != 1 {
+		return helpers.ErrAccessCodeNotValid
+	}
+
+	return nil
+}
+
+// encryptSplitSecret creates a new random key of the given new secret, encrypts the given value with a new random
+// data key, and splits the data key into the shares of the secret. Each share has its own random key and access code
+// (created by the given policy), and is encrypted with a key derived from its access code, so the data key can only
+// be assembled from the threshold of the shares with their access codes. It returns the shares and their access codes.
+func (a *Application) encryptSplitSecret(secret *database.Secret, value string, policy *helpers.AccessCodePolicy) (shares []*database.SecretShare, accessCodes []string, err error) {
+	// Create a new random key.
+	secret.Key, err = helpers.GenerateRandomString(constants.ConstSecretKeyLength)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Hash a new random access code, which is never shared (the secret is unlocked by the access codes of its shares).
+	unusedAccessCode, err := helpers.GenerateAccessCode(policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret.AccessCode, err = helpers.HashAccessCode(unusedAccessCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate a new data key for the secret.
+	dataKey, err := helpers.GenerateDataKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Encrypt the secret value with the data key.
+	secret.Value, err = helpers.EncryptString(dataKey, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Split the data key into the shares.
+	dataKeyShares, err := helpers.SplitSecret(dataKey, secret.SharesTotal, secret.SharesThreshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Encrypt each share with a key derived from its own access code.
+	for i, dataKeyShare := range dataKeyShares {
+		share := &database.SecretShare{Number: i + 1}
+
+		// Create a new random access code and key of the share.
+		accessCode, err := helpers.GenerateAccessCode(policy)
+		if err != nil {
+			return nil, nil, err
+		}
+		share.Key, err = helpers.GenerateRandomString(constants.ConstSecretKeyLength)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Derive a new key from the access code and encrypt the share with it.
+		accessCodeKey, accessCodeHash, err := helpers.DeriveAccessCodeKey(accessCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		share.AccessCode = accessCodeHash
+		share.Value, err = helpers.EncryptString(accessCodeKey, base64.RawStdEncoding.EncodeToString(dataKeyShare))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		shares = append(shares, share)
+		accessCodes = append(accessCodes, accessCode)
+	}
+
+	return shares, accessCodes, nil
+}
+
+// decryptSecretShare verifies the given access code of the given share, and returns the decrypted share.
+func (a *Application) decryptSecretShare(share *database.SecretShare, accessCode string) ([]byte, error) {
+	// Verify the access code and derive the access code key.
+	accessCodeKey, err := helpers.VerifyAccessCodeKey(accessCode, share.AccessCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt the share with the access code key.
+	shareEncoded, err := helpers.DecryptString(accessCodeKey, share.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.RawStdEncoding.DecodeString(shareEncoded)
+}
+
+// decryptSplitSecretValue assembles the data key from the given shares, and decrypts the value of the given secret.
+func (a *Application) decryptSplitSecretValue(secret *database.Secret, shares [][]byte) (string, error) {
+	// Assemble the data key from the shares.
+	dataKey, err := helpers.CombineShares(shares)
+	if err != nil {
+		return "", err
+	}
+
+	// Clear the data key, after the value is decrypted.
+	defer clear(dataKey)
+
+	return helpers.DecryptString(dataKey, secret.Value)
+}
+
+// isSecretValueEditable returns true if the value of the given secret can be re-encrypted by the server. The values
+// encrypted in the browser or split into shares cannot be re-encrypted, because the server never has their keys.
+func isSecretValueEditable(secret *database.Secret) bool {
+	return !secret.IsClientEncrypted && secret.SharesTotal == 0
+}
+
+// encryptEditedSecret encrypts the given new value of the edited secret with a new random data key, like on the
+// secret creation. The value of the secret encrypted to the recipient is encrypted to the same recipient again by
+// the given public key, and the value of the secret protected by the access code needs the given access code.
+func (a *Application) encryptEditedSecret(secret *database.Secret, value, accessCode, recipientPublicKey string) (err error) {
+	// Check, if the secret is encrypted to the recipient.
+	if secret.RecipientType != "" {
+		// Encrypt the new value to the recipient public key.
+		recipientType, recipient, ciphertext, err := helpers.EncryptToRecipient(recipientPublicKey, value)
+		if err != nil {
+			return err
+		}
+
+		// Check, if the public key belongs to the same recipient.
+		if recipientType != secret.RecipientType || recipient != secret.Recipient {
+			return errors.New(messages.ErrSecretRecipientNotMatched)
+		}
+		value = ciphertext
+	}
+
+	// Check, if the secret is protected by the access code.
+	if secret.IsAccessCodeProtected {
+		// Verify the access code by decrypting the current value.
+		if _, err := a.decryptProtectedSecretValue(secret, accessCode); err != nil {
+			return err
+		}
+
+		// Encrypt the new value with a new data key protected by the same access code.
+		secret.Value, secret.DataKey, secret.AccessCode, err = a.encryptProtectedSecretValue(value, accessCode)
+
+		return err
+	}
+
+	// Encrypt the new value with a new data key.
+	secret.Value, secret.DataKey, err = a.encryptSecretValue(value)
+
+	return err
+}
@@ -0,0 +1,471 @@
+package application
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// fileStore keeps the encrypted files of the secrets on disk in its folder, one file per random storage key.
+// The files, which are uploaded, but not added to the database yet, are pending, so they are not removed by the sweep.
+type fileStore struct {
+	folder  string
+	mu      sync.Mutex
+	pending map[string]struct{}
+}
+
+// newFileStore returns a new file store in the given folder (the folder is created with the first file).
+func newFileStore(folder string) *fileStore {
+	return &fileStore{
+		folder:  folder,
+		pending: make(map[string]struct{}),
+	}
+}
+
+// path returns the path of the encrypted file by its storage key.
+func (s *fileStore) path(storageKey string) string {
+	return filepath.Join(s.folder, storageKey)
+}
+
+// create creates a new pending file with a new random storage key, which is readable only by the owner.
+func (s *fileStore) create() (storageKey string, file *os.File, err error) {
+	// Create the folder of the files, if it does not exist.
+	if err := os.MkdirAll(s.folder, 0o700); err != nil {
+		return "", nil, err
+	}
+
+	// Create a new random storage key.
+	storageKey, err = helpers.GenerateRandomString(constants.ConstSecretFilesStorageKeyLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Create a new file (the existing file with the same storage key is never overwritten).
+	file, err = os.OpenFile(s.path(storageKey), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", nil, err
+	}
+	s.pending[storageKey] = struct{}{}
+
+	return storageKey, file, nil
+}
+
+// open opens the encrypted file by its storage key for reading.
+func (s *fileStore) open(storageKey string) (*os.File, error) {
+	return os.Open(s.path(storageKey))
+}
+
+// release marks the pending files by their storage keys as stored, after their records are added to the database.
+func (s *fileStore) release(storageKeys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, storageKey := range storageKeys {
+		delete(s.pending, storageKey)
+	}
+}
+
+// remove removes the files by their storage keys from disk.
+func (s *fileStore) remove(storageKeys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, storageKey := range storageKeys {
+		if err := os.Remove(s.path(storageKey)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Error("failed to remove secret file", "storage_key", storageKey, "details", err.Error())
+		}
+		delete(s.pending, storageKey)
+	}
+}
+
+// stored returns the storage keys of the files on disk, which are not pending.
+func (s *fileStore) stored() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Read the folder of the files.
+	entries, err := os.ReadDir(s.folder)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the storage keys of the files, which are not pending (other files in the folder are skipped).
+	storageKeys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		storageKey := entry.Name()
+		if _, ok := s.pending[storageKey]; ok || !entry.Type().IsRegular() ||
+			helpers.IsSecretKeyValid(storageKey, constants.ConstSecretFilesStorageKeyLength) != nil {
+			continue
+		}
+		storageKeys = append(storageKeys, storageKey)
+	}
+
+	return storageKeys, nil
+}
+
+// sweepSecretFiles removes the encrypted files from disk, which have no records in the database anymore
+// (the records are deleted together with their secrets, or when the expired secrets are wiped).
+func (a *Application) sweepSecretFiles() {
+	// Get the files on disk before the records, so the files added in between are not removed.
+	storageKeys, err := a.secretFiles.stored()
+	if err != nil {
+		slog.Error("failed to sweep secret files", "details", err.Error())
+		return
+	}
+	if len(storageKeys) == 0 {
+		return
+	}
+
+	// Get the storage keys of the files from the database.
+	knownStorageKeys, err := a.Database.QueryGetSecretFileStorageKeys()
+	if err != nil {
+		slog.Error("failed to sweep secret files", "details", err.Error())
+		return
+	}
+
+	// Remove the files without records.
+	var removed int
+	for _, storageKey := range storageKeys {
+		if !slices.Contains(knownStorageKeys, storageKey) {
+			a.secretFiles.remove(storageKey)
+			removed++
+		}
+	}
+
+	// Log the removed files.
+	if removed > 0 {
+		slog.Info("swept secret files", "count", removed)
+	}
+}
+
+// secretFileUpload represents the uploaded file of the new secret, which is encrypted on disk with its own random key.
+type secretFileUpload struct {
+	Name, StorageKey string
+	Size             int64
+	Key              []byte
+}
+
+// parseAddSecretForm parses the form data of the add secret form. The form with files is read part by part, and each
+// file is encrypted to disk while it is uploaded (the plain files are never kept in memory or in temporary files).
+// It returns the uploaded files, which should be removed by the caller, if the secret is not added.
+func (a *Application) parseAddSecretForm(w http.ResponseWriter, r *http.Request) (uploads []*secretFileUpload, err error) {
+	// Replace the error of the too large form data with the error of the exceeded size of the files.
+	defer func() {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = a.secretFilesLimitError()
+		}
+	}()
+
+	// Check, if the form has files.
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return nil, r.ParseForm()
+	}
+
+	// Give more time to upload the files, and limit the size of the form data.
+	a.extendTransferDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, a.Config.SecretFiles.MaxSize+constants.ConstSecretFilesFormFieldsMaxSize)
+
+	// Get a reader of the form parts.
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill the form values like the ParseForm function (with the URL query values).
+	r.Form, r.PostForm = r.URL.Query(), url.Values{}
+
+	// Read the form parts.
+	var size int64
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return uploads, nil
+		}
+		if err != nil {
+			return uploads, err
+		}
+
+		// Read the text field, or skip the empty file input.
+		if part.FileName() == "" {
+			if part.FormName() == "files" {
+				continue
+			}
+			value, err := io.ReadAll(part)
+			if err != nil {
+				return uploads, err
+			}
+			r.Form.Add(part.FormName(), string(value))
+			r.PostForm.Add(part.FormName(), string(value))
+			continue
+		}
+
+		// Skip the unknown file inputs.
+		if part.FormName() != "files" {
+			continue
+		}
+
+		// Check, if the files are enabled and the number of the files is not exceeded.
+		if a.Config.SecretFiles.MaxSize == 0 {
+			return uploads, errors.New(messages.ErrFormAddSecretFilesNotEnabled)
+		}
+		if len(uploads) == constants.ConstSecretFilesMaxCount {
+			return uploads, a.secretFilesLimitError()
+		}
+
+		// Encrypt the file to disk with the rest of the allowed size.
+		upload, err := a.encryptSecretFile(part, part.FileName(), a.Config.SecretFiles.MaxSize-size)
+		if err != nil {
+			return uploads, err
+		}
+		uploads = append(uploads, upload)
+		size += upload.Size
+	}
+}
+
+// encryptSecretFile encrypts the file from the given reader with a new random key to a new pending file on disk.
+// It returns an error, if the file is larger than the given maximum size (the encrypted file is removed).
+func (a *Application) encryptSecretFile(src io.Reader, name string, maxSize int64) (*secretFileUpload, error) {
+	// Generate a new key for the file.
+	key, err := helpers.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new pending file on disk.
+	storageKey, file, err := a.secretFiles.create()
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the file (one byte more than the maximum size is read to check the size).
+	size, err := helpers.EncryptStream(key, file, io.LimitReader(src, maxSize+1))
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > maxSize {
+		err = a.secretFilesLimitError()
+	}
+	if err != nil {
+		a.secretFiles.remove(storageKey)
+		return nil, err
+	}
+
+	return &secretFileUpload{
+		Name:       secretFileName(name),
+		StorageKey: storageKey,
+		Size:       size,
+		Key:        key,
+	}, nil
+}
+
+// removeSecretFileUploads removes the encrypted files of the given uploads from disk.
+func (a *Application) removeSecretFileUploads(uploads []*secretFileUpload) {
+	for _, upload := range uploads {
+		a.secretFiles.remove(upload.StorageKey)
+	}
+}
+
+// secretFilesLimitError returns the error of the exceeded number or total size of the secret files.
+func (a *Application) secretFilesLimitError() error {
+	return fmt.Errorf(
+		messages.ErrFormAddSecretFilesNotValid,
+		constants.ConstSecretFilesMaxCount, helpers.FormatSize(a.Config.SecretFiles.MaxSize),
+	)
+}
+
+// secretFileName returns the valid UTF-8 name of the uploaded file, which fits the 'name' field of the file record.
+func secretFileName(name string) string {
+	name = strings.ToValidUTF8(strings.TrimSpace(name), "_")
+	for utf8.RuneCountInString(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
+
+// wrapSecretFileKeys sets the file records of the given new secret from the uploaded files, and wraps their keys
+// like the data key of the secret. The keys of the secret protected by the access code are wrapped by a key
+// derived from the given access code (with its own salt, so the files do not depend on the value of the secret).
+func (a *Application) wrapSecretFileKeys(secret *database.Secret, uploads []*secretFileUpload, accessCode string) (err error) {
+	secret.Files = nil
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	// Derive a new key from the access code, if the secret is protected by it.
+	var accessCodeKey []byte
+	var accessCodeHash string
+	if secret.IsAccessCodeProtected {
+		accessCodeKey, accessCodeHash, err = helpers.DeriveAccessCodeKey(accessCode)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, upload := range uploads {
+		file := &database.SecretFile{
+			CreatedAt:  secret.CreatedAt,
+			Name:       upload.Name,
+			Size:       upload.Size,
+			StorageKey: upload.StorageKey,
+			AccessCode: accessCodeHash,
+		}
+
+		// Check, if the secret is protected by the access code.
+		if secret.IsAccessCodeProtected {
+			// Wrap the key with the access code key, and then with the active key.
+			keyProtected, err := helpers.EncryptString(accessCodeKey, base64.RawStdEncoding.EncodeToString(upload.Key))
+			if err != nil {
+				return err
+			}
+			file.DataKey, err = a.Keyring.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, keyProtected)
+			if err != nil {
+				return err
+			}
+		} else {
+			// Wrap the key with the active key.
+			file.DataKey, err = a.Keyring.WrapKey(upload.Key)
+			if err != nil {
+				return err
+			}
+		}
+
+		secret.Files = append(secret.Files, file)
+	}
+
+	return nil
+}
+
+// unwrapSecretFileKey returns the key of the given file. The key of the file protected by the access code
+// is unwrapped with the given access code key.
+func (a *Application) unwrapSecretFileKey(file *database.SecretFile, accessCodeKey []byte) ([]byte, error) {
+	// Check, if the file is not protected by the access code.
+	if file.AccessCode == "" {
+		return a.Keyring.UnwrapKey(file.DataKey)
+	}
+
+	// Unwrap the protected key with the keyring, and then with the access code key.
+	keyProtected, err := a.Keyring.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, file.DataKey)
+	if err != nil {
+		return nil, err
+	}
+	keyEncoded, err := helpers.DecryptString(accessCodeKey, keyProtected)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.RawStdEncoding.DecodeString(keyEncoded)
+}
+
+// secretFileGrantSessionKey returns the session key of the download grant of the secret file.
+func secretFileGrantSessionKey(key string, id int) string {
+	return "secret_file:" + key + ":" + strconv.Itoa(id)
+}
+
+// unlockSecretFiles gets the files of the secret, which is being unlocked, and unwraps their keys. The keys of the files
+// protected by the access code need the given access code. It is called before the view of the secret is used, so the
+// secret is not consumed, if its files cannot be granted. It returns the files and their keys by the file IDs.
+func (a *Application) unlockSecretFiles(secret *database.Secret, accessCode string) ([]*database.SecretFile, map[int][]byte, error) {
+	// Get the files of the secret from the database.
+	files, err := a.Database.QueryGetSecretFilesBySecretID(secret.ID)
+	if err != nil || len(files) == 0 {
+		return nil, nil, err
+	}
+
+	var accessCodeKey []byte
+	keys := make(map[int][]byte, len(files))
+	for _, file := range files {
+		// Derive the access code key once (all files of the secret are protected with the same access code hash).
+		if file.AccessCode != "" && accessCodeKey == nil {
+			accessCodeKey, err = helpers.VerifyAccessCodeKey(accessCode, file.AccessCode)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Unwrap the key of the file.
+		key, err := a.unwrapSecretFileKey(file, accessCodeKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys[file.ID] = key
+	}
+
+	return files, keys, nil
+}
+
+// grantSecretFiles keeps the given keys of the files of the unlocked secret in the session of the visitor
+// for the download time, so the files can only be downloaded in the same browser after the secret is unlocked.
+func (a *Application) grantSecretFiles(r *http.Request, key string, keys map[int][]byte) {
+	// Get the expiration time of the download grants.
+	expiresAt := time.Now().Add(time.Duration(constants.ConstSecretFilesDownloadTTL) * time.Minute).Unix()
+
+	for id, fileKey := range keys {
+		// Keep the key with the expiration time in the session.
+		a.Session.Manager.Put(
+			r.Context(), secretFileGrantSessionKey(key, id),
+			strconv.FormatInt(expiresAt, 10)+"|"+base64.RawStdEncoding.EncodeToString(fileKey),
+		)
+	}
+}
+
+// secretFileGrant returns the key of the secret file from the download grant in the session.
+// It returns an error, if the grant is missing or expired (the expired grant is removed).
+func (a *Application) secretFileGrant(r *http.Request, key string, id int) ([]byte, error) {
+	sessionKey := secretFileGrantSessionKey(key, id)
+
+	// Get the expiration time and the key of the file from the session.
+	expiresAt, keyEncoded, found := strings.Cut(a.Session.Manager.GetString(r.Context(), sessionKey), "|")
+	expiresAtUnix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if !found || err != nil || time.Now().Unix() > expiresAtUnix {
+		a.Session.Manager.Remove(r.Context(), sessionKey)
+		return nil, errors.New(messages.ErrSecretFileNotGranted)
+	}
+
+	return base64.RawStdEncoding.DecodeString(keyEncoded)
+}
+
+// extendTransferDeadlines gives more time to read the request and write the response with the files
+// (instead of the server read and write timeouts).
+func (a *Application) extendTransferDeadlines(w http.ResponseWriter) {
+	deadline := time.Now().Add(time.Duration(constants.ConstSecretFilesTransferTimeout) * time.Second)
+
+	// Set the new deadlines (the response writer, which does not support them, is skipped).
+	controller := http.NewResponseController(w)
+	if err := controller.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Error("failed to set read deadline", "details", err.Error())
+	}
+	if err := controller.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Error("failed to set write deadline", "details", err.Error())
+	}
+}
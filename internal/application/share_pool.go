@@ -0,0 +1,75 @@
+package application
+
+import (
+	"sync"
+	"time"
+)
+
+// sharePool keeps the submitted shares of the split secrets in memory, until enough shares are submitted
+// to assemble the secret. The shares are never stored in the database in the decrypted form.
+type sharePool struct {
+	mu      sync.Mutex
+	entries map[int]*sharePoolEntry
+}
+
+// sharePoolEntry represents the submitted shares of one split secret.
+type sharePoolEntry struct {
+	shares    map[int][]byte
+	expiresAt time.Time
+}
+
+// newSharePool returns a new empty share pool.
+func newSharePool() *sharePool {
+	return &sharePool{
+		entries: make(map[int]*sharePoolEntry),
+	}
+}
+
+// add adds the given share (by its number) of the split secret to the pool. If the number of the submitted shares
+// reaches the threshold, it removes the entry from the pool and returns all shares of the secret to assemble it.
+// Otherwise, it returns the number of the submitted shares.
+func (p *sharePool) add(secretID, number int, share []byte, threshold int, ttl time.Duration) (shares [][]byte, submitted int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	// Remove the expired entries.
+	for id, entry := range p.entries {
+		if now.After(entry.expiresAt) {
+			entry.clear()
+			delete(p.entries, id)
+		}
+	}
+
+	// Get the entry of the secret or create a new one.
+	entry, ok := p.entries[secretID]
+	if !ok {
+		entry = &sharePoolEntry{shares: make(map[int][]byte)}
+		p.entries[secretID] = entry
+	}
+
+	// Add the share and extend the lifetime of the entry.
+	entry.shares[number] = share
+	entry.expiresAt = now.Add(ttl)
+
+	// Check, if the number of the submitted shares is less than the threshold.
+	if len(entry.shares) < threshold {
+		return nil, len(entry.shares)
+	}
+
+	// Remove the entry and return all shares.
+	delete(p.entries, secretID)
+	for _, s := range entry.shares {
+		shares = append(shares, s)
+	}
+
+	return shares, len(shares)
+}
+
+// clear overwrites the shares of the entry with zeros.
+func (e *sharePoolEntry) clear() {
+	for _, share := range e.shares {
+		clear(share)
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"errors"
-	"os"
+	"fmt"
+	"slices"
 	"strconv"
+	"time"
 	_ "time/tzdata"
 
 	"github.com/secretium/secretium/internal/constants"
@@ -11,10 +13,46 @@ import (
 	"github.com/secretium/secretium/internal/messages"
 )
 
-// Config contains secret key, master password, domain and server configuration.
+// Config contains key provider, secret keys, master password, domain, access code policy, secret TTL, failed attempts,
+// secret files, janitor and server configuration.
 type Config struct {
-	SecretKey, MasterUsername, MasterPassword, Domain, DomainSchema string
-	Server                                                          *server
+	KeyProvider, SecretKey                               string
+	MasterUsername, MasterPassword, Domain, DomainSchema string
+	PreviousSecretKeys                                   []string
+	AccessCodePolicy                                     *helpers.AccessCodePolicy
+	Vault                                                *vault
+	SecretTTL                                            *secretTTL
+	FailedAttempts                                       *failedAttempts
+	SecretFiles                                          *secretFiles
+	Janitor                                              *janitor
+	Server                                               *server
+}
+
+// Vault contains address, token, mount path and key name of the Vault transit engine.
+type vault struct {
+	Address, Token, TransitMount, TransitKey string
+}
+
+// SecretTTL contains minimum and maximum time to live of the secrets, and their maximum total lifetime through renewals.
+type secretTTL struct {
+	Min, Max, MaxLifetime time.Duration
+}
+
+// FailedAttempts contains limit of the failed access code attempts of the secret and action, when the limit is reached.
+type failedAttempts struct {
+	Limit  int
+	Action string
+}
+
+// SecretFiles contains maximum total size in bytes of the files attached to a secret (zero means, that the files are disabled).
+type secretFiles struct {
+	MaxSize int64
+}
+
+// Janitor contains mode, retention period of the expired secrets and interval between the runs.
+type janitor struct {
+	Mode                      string
+	RetentionPeriod, Interval time.Duration
 }
 
 // Server contains port, read and write timeout.
@@ -47,12 +85,107 @@ func New() (*Config, error) {
 		return nil, errors.New(messages.ErrConfigServerWriteTimeoutNotValid)
 	}
 
+	// Validate access code policy.
+	accessCodePolicy, err := helpers.ParseAccessCodePolicy(
+		helpers.Getenv("ACCESS_CODE_TYPE", constants.ConstConfigAccessCodeType),
+		helpers.Getenv("ACCESS_CODE_LENGTH", constants.ConstConfigAccessCodeLength),
+		helpers.Getenv("ACCESS_CODE_CHARACTER_CLASSES", constants.ConstConfigAccessCodeCharacterClasses),
+		helpers.Getenv("ACCESS_CODE_EXCLUDE_AMBIGUOUS", constants.ConstConfigAccessCodeExcludeAmbiguous),
+		helpers.Getenv("ACCESS_CODE_WORDS", constants.ConstConfigAccessCodeWords),
+		helpers.Getenv("ACCESS_CODE_WORD_SEPARATOR", constants.ConstConfigAccessCodeWordSeparator),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate secret minimum and maximum TTL, and maximum lifetime.
+	secretMinTTL, err := time.ParseDuration(helpers.Getenv("SECRET_MIN_TTL", constants.ConstConfigSecretMinTTL))
+	if err != nil || secretMinTTL < time.Duration(constants.ConstSecretMinTTLMin)*time.Second {
+		return nil, fmt.Errorf(messages.ErrConfigSecretTTLNotValid, constants.ConstSecretMinTTLMin)
+	}
+	secretMaxTTL, err := time.ParseDuration(helpers.Getenv("SECRET_MAX_TTL", constants.ConstConfigSecretMaxTTL))
+	if err != nil || secretMaxTTL < secretMinTTL {
+		return nil, fmt.Errorf(messages.ErrConfigSecretTTLNotValid, constants.ConstSecretMinTTLMin)
+	}
+
+	secretMaxLifetime, err := time.ParseDuration(helpers.Getenv("SECRET_MAX_LIFETIME", constants.ConstConfigSecretMaxLifetime))
+	if err != nil || (secretMaxLifetime != 0 && secretMaxLifetime < secretMaxTTL) {
+		return nil, errors.New(messages.ErrConfigSecretMaxLifetimeNotValid)
+	}
+
+	// Validate failed attempts limit and action.
+	failedAttemptsLimit, err := strconv.Atoi(helpers.Getenv("FAILED_ATTEMPTS_LIMIT", constants.ConstConfigFailedAttemptsLimit))
+	if err != nil || failedAttemptsLimit < 0 {
+		return nil, errors.New(messages.ErrConfigFailedAttemptsLimitNotValid)
+	}
+
+	failedAttemptsAction := helpers.Getenv("FAILED_ATTEMPTS_ACTION", constants.ConstConfigFailedAttemptsAction)
+	if !slices.Contains(
+		[]string{constants.ConstFailedAttemptsActionLock, constants.ConstFailedAttemptsActionDestroy},
+		failedAttemptsAction,
+	) {
+		return nil, errors.New(messages.ErrConfigFailedAttemptsActionNotValid)
+	}
+
+	// Validate secret files maximum size.
+	secretFilesMaxSize, err := strconv.ParseInt(helpers.Getenv("SECRET_FILES_MAX_SIZE", constants.ConstConfigSecretFilesMaxSize), 10, 64)
+	if err != nil || secretFilesMaxSize < 0 || secretFilesMaxSize > 1024*1024 {
+		return nil, errors.New(messages.ErrConfigSecretFilesMaxSizeNotValid)
+	}
+
+	// Validate janitor mode.
+	janitorMode := helpers.Getenv("JANITOR_MODE", constants.ConstConfigJanitorMode)
+	if !slices.Contains(
+		[]string{constants.ConstJanitorModeOff, constants.ConstJanitorModeDelete, constants.ConstJanitorModeWipe},
+		janitorMode,
+	) {
+		return nil, errors.New(messages.ErrConfigJanitorModeNotValid)
+	}
+
+	// Validate janitor retention period.
+	janitorRetentionPeriod, err := time.ParseDuration(helpers.Getenv("JANITOR_RETENTION_PERIOD", constants.ConstConfigJanitorRetentionPeriod))
+	if err != nil || janitorRetentionPeriod < 0 {
+		return nil, errors.New(messages.ErrConfigJanitorRetentionPeriodNotValid)
+	}
+
+	// Validate janitor interval.
+	janitorInterval, err := time.ParseDuration(helpers.Getenv("JANITOR_INTERVAL", constants.ConstConfigJanitorInterval))
+	if err != nil || janitorInterval < time.Duration(constants.ConstJanitorIntervalMin)*time.Second {
+		return nil, fmt.Errorf(messages.ErrConfigJanitorIntervalNotValid, constants.ConstJanitorIntervalMin)
+	}
+
 	return &Config{
-		SecretKey:      os.Getenv("SECRET_KEY"),
-		MasterUsername: os.Getenv("MASTER_USERNAME"),
-		MasterPassword: os.Getenv("MASTER_PASSWORD"),
-		Domain:         helpers.Getenv("DOMAIN", constants.ConstConfigDomain),
-		DomainSchema:   helpers.Getenv("DOMAIN_SCHEMA", constants.ConstConfigDomainSchema),
+		KeyProvider:        helpers.Getenv("KEY_PROVIDER", constants.ConstConfigKeyProvider),
+		SecretKey:          helpers.Getenv("SECRET_KEY", ""),
+		PreviousSecretKeys: helpers.SplitList(helpers.Getenv("SECRET_KEYS_PREVIOUS", "")),
+		MasterUsername:     helpers.Getenv("MASTER_USERNAME", ""),
+		MasterPassword:     helpers.Getenv("MASTER_PASSWORD", ""),
+		Domain:             helpers.Getenv("DOMAIN", constants.ConstConfigDomain),
+		DomainSchema:       helpers.Getenv("DOMAIN_SCHEMA", constants.ConstConfigDomainSchema),
+		AccessCodePolicy:   accessCodePolicy,
+		Vault: &vault{
+			Address:      helpers.Getenv("VAULT_ADDR", ""),
+			Token:        helpers.Getenv("VAULT_TOKEN", ""),
+			TransitMount: helpers.Getenv("VAULT_TRANSIT_MOUNT", constants.ConstConfigVaultTransitMount),
+			TransitKey:   helpers.Getenv("VAULT_TRANSIT_KEY", ""),
+		},
+		SecretTTL: &secretTTL{
+			Min:         secretMinTTL,
+			Max:         secretMaxTTL,
+			MaxLifetime: secretMaxLifetime,
+		},
+		FailedAttempts: &failedAttempts{
+			Limit:  failedAttemptsLimit,
+			Action: failedAttemptsAction,
+		},
+		SecretFiles: &secretFiles{
+			MaxSize: secretFilesMaxSize * 1024 * 1024,
+		},
+		Janitor: &janitor{
+			Mode:            janitorMode,
+			RetentionPeriod: janitorRetentionPeriod,
+			Interval:        janitorInterval,
+		},
 		Server: &server{
 			Port:         port,
 			ReadTimeout:  readTimeout,
@@ -39,9 +39,260 @@ const (
 	// ConstConfigServerTimezone is the server timezone.
 	ConstConfigServerTimezone string = "Europe/Moscow"
 
+	// ConstConfigAccessCodeType is the default type of the generated access codes.
+	ConstConfigAccessCodeType string = ConstAccessCodePolicyTypeCharacters
+
+	// ConstConfigAccessCodeLength is the default length of the generated access codes.
+	ConstConfigAccessCodeLength string = "10"
+
+	// ConstConfigAccessCodeCharacterClasses is the default character classes of the generated access codes.
+	ConstConfigAccessCodeCharacterClasses string = "lower,digits"
+
+	// ConstConfigAccessCodeExcludeAmbiguous is the default flag to exclude the ambiguous characters from the access codes.
+	ConstConfigAccessCodeExcludeAmbiguous string = "true"
+
+	// ConstConfigAccessCodeWords is the default number of words of the generated passphrases.
+	ConstConfigAccessCodeWords string = "4"
+
+	// ConstConfigAccessCodeWordSeparator is the default separator of the words of the generated passphrases.
+	ConstConfigAccessCodeWordSeparator string = "-"
+
+	// ConstConfigKeyProvider is the default provider of the secret key.
+	ConstConfigKeyProvider string = ConstKeyProviderEnv
+
+	// ConstConfigVaultTransitMount is the default mount path of the Vault transit secrets engine.
+	ConstConfigVaultTransitMount string = "transit"
+
+	// ConstConfigSecretMinTTL is the default minimum time to live of the secrets.
+	ConstConfigSecretMinTTL string = "5m"
+
+	// ConstConfigSecretMaxTTL is the default maximum time to live of the secrets.
+	ConstConfigSecretMaxTTL string = "720h"
+
+	// ConstConfigSecretMaxLifetime is the default maximum total lifetime of the secrets through renewals (zero means unlimited).
+	ConstConfigSecretMaxLifetime string = "0"
+
+	// ConstConfigJanitorMode is the default mode of the janitor, which purges the expired secrets.
+	ConstConfigJanitorMode string = ConstJanitorModeOff
+
+	// ConstConfigJanitorRetentionPeriod is the default period, how long the expired secrets are kept before purging.
+	ConstConfigJanitorRetentionPeriod string = "720h"
+
+	// ConstConfigJanitorInterval is the default interval between the runs of the janitor.
+	ConstConfigJanitorInterval string = "1h"
+
+	// ConstConfigFailedAttemptsLimit is the default number of the failed access code attempts, after which the secret
+	// is locked or destroyed (zero means unlimited attempts, so the lockout is opt-in).
+	ConstConfigFailedAttemptsLimit string = "0"
+
+	// ConstConfigFailedAttemptsAction is the default action with the secret, when the failed attempts limit is reached.
+	ConstConfigFailedAttemptsAction string = ConstFailedAttemptsActionLock
+
+	// ConstConfigSecretFilesMaxSize is the default maximum total size in MiB of the files attached to a secret
+	// (zero means, that the files are disabled).
+	ConstConfigSecretFilesMaxSize string = "10"
+
 	// ConstConfigSQLitePath is the path to the SQLite database.
 	ConstConfigSQLitePath string = "secretium-data"
 
+	/*
+		Encryption constants.
+	*/
+
+	// ConstEncryptionCiphertextVersion is the version prefix of the encrypted values envelope.
+	ConstEncryptionCiphertextVersion string = "v3"
+
+	// ConstEncryptionKeyIDCiphertextVersion is the version prefix of the encrypted values envelope with a key ID.
+	ConstEncryptionKeyIDCiphertextVersion string = "v4"
+
+	// ConstEncryptionVaultCiphertextVersion is the version prefix of the envelope encrypted by the Vault transit engine.
+	ConstEncryptionVaultCiphertextVersion string = "v5"
+
+	// ConstEncryptionKeyIDLength is the length of the key ID derived from the secret key.
+	ConstEncryptionKeyIDLength int = 8
+
+	// ConstEncryptionRotationBatchSize is the number of secrets re-encrypted in one batch by the key rotation.
+	ConstEncryptionRotationBatchSize int = 100
+
+	// ConstEncryptionKeySize is the size of the derived subkeys in bytes (AES-256).
+	ConstEncryptionKeySize int = 32
+
+	// ConstEncryptionKeyDerivationSalt is the HKDF salt for deriving subkeys from the secret key.
+	ConstEncryptionKeyDerivationSalt string = "secretium"
+
+	// ConstEncryptionKeyPurposeValue is the HKDF info for the secret value subkey.
+	ConstEncryptionKeyPurposeValue string = "secretium/value"
+
+	// ConstEncryptionKeyPurposeAccessCode is the HKDF info for the secret access code subkey.
+	ConstEncryptionKeyPurposeAccessCode string = "secretium/access-code"
+
+	// ConstEncryptionKeyPurposeDataKey is the HKDF info for the subkey, which wraps the data keys of the secrets.
+	ConstEncryptionKeyPurposeDataKey string = "secretium/data-key"
+
+	// ConstEncryptionKeyPurposeKeyID is the HKDF info for the key ID.
+	ConstEncryptionKeyPurposeKeyID string = "secretium/key-id"
+
+	/*
+		Key provider constants.
+	*/
+
+	// ConstKeyProviderEnv is the key provider, which reads the secret key from the SECRET_KEY environment variable.
+	ConstKeyProviderEnv string = "env"
+
+	// ConstKeyProviderFile is the key provider, which reads the secret key from the file (for example, Docker secrets).
+	ConstKeyProviderFile string = "file"
+
+	// ConstKeyProviderVault is the key provider, which encrypts with the key of the HashiCorp Vault transit engine.
+	ConstKeyProviderVault string = "vault"
+
+	// ConstKeyProviderVaultRequestTimeout is the timeout in seconds of the requests to the Vault transit engine.
+	ConstKeyProviderVaultRequestTimeout int = 10
+
+	/*
+		Janitor constants.
+	*/
+
+	// ConstJanitorModeOff is the janitor mode, which keeps the expired secrets until they are deleted manually.
+	ConstJanitorModeOff string = "off"
+
+	// ConstJanitorModeDelete is the janitor mode, which permanently deletes the expired secrets.
+	ConstJanitorModeDelete string = "delete"
+
+	// ConstJanitorModeWipe is the janitor mode, which wipes the encrypted fields of the expired secrets,
+	// but keeps their metadata (name, key and dates) for the expired list of the dashboard.
+	ConstJanitorModeWipe string = "wipe"
+
+	// ConstJanitorIntervalMin is the minimum interval in seconds between the runs of the janitor.
+	ConstJanitorIntervalMin int = 60
+
+	/*
+		Failed attempts constants.
+	*/
+
+	// ConstFailedAttemptsActionLock is the failed attempts action, which locks the secret until it is unlocked from the dashboard.
+	ConstFailedAttemptsActionLock string = "lock"
+
+	// ConstFailedAttemptsActionDestroy is the failed attempts action, which permanently deletes the secret.
+	ConstFailedAttemptsActionDestroy string = "destroy"
+
+	/*
+		Bulk actions constants.
+	*/
+
+	// ConstBulkActionExpire is the bulk action, which expires the selected active secrets.
+	ConstBulkActionExpire string = "expire"
+
+	// ConstBulkActionRenew is the bulk action, which renews the selected secrets.
+	ConstBulkActionRenew string = "renew"
+
+	// ConstBulkActionDelete is the bulk action, which permanently deletes the selected secrets.
+	ConstBulkActionDelete string = "delete"
+
+	// ConstBulkActionPurgeExpired is the bulk action, which permanently deletes all expired secrets (no keys are selected).
+	ConstBulkActionPurgeExpired string = "purge-expired"
+
+	// ConstBulkActionMaxKeys is the maximum number of the secrets selected for the bulk action.
+	ConstBulkActionMaxKeys int = 100
+
+	/*
+		Secret constants.
+	*/
+
+	// ConstSecretMinTTLMin is the lowest allowed minimum time to live in seconds of the secrets.
+	ConstSecretMinTTLMin int = 60
+
+	// ConstSecretRenewDefault is the default duration of the secret renewal.
+	ConstSecretRenewDefault string = "1d"
+
+	// ConstSecretKeyLength is the length of the random secret key (used in the share URL).
+	ConstSecretKeyLength int = 16
+
+	// ConstSecretAddMaxAttempts is the maximum number of attempts to add a secret with a new random key,
+	// if the generated key (or access code) is already taken by another secret.
+	ConstSecretAddMaxAttempts int = 5
+
+	// ConstSecretSharesMinThreshold is the minimum number of shares to unlock the split secret.
+	ConstSecretSharesMinThreshold int = 2
+
+	// ConstSecretSharesMaxTotal is the maximum number of shares of the split secret.
+	ConstSecretSharesMaxTotal int = 10
+
+	// ConstSecretSharesPoolTTL is the time in minutes to keep the submitted shares in memory,
+	// while waiting for the other shares of the split secret.
+	ConstSecretSharesPoolTTL int = 15
+
+	// ConstSecretRevealTokenLength is the length of the random per-page token,
+	// which is required to unlock the secret from its page.
+	ConstSecretRevealTokenLength int = 32
+
+	// ConstSecretFilesFolder is the name of the folder (in the SQLite DB folder) with the encrypted files of the secrets.
+	ConstSecretFilesFolder string = "files"
+
+	// ConstSecretFilesMaxCount is the maximum number of the files attached to a secret.
+	ConstSecretFilesMaxCount int = 5
+
+	// ConstSecretFilesStorageKeyLength is the length of the random storage key (the name of the encrypted file on disk).
+	ConstSecretFilesStorageKeyLength int = 32
+
+	// ConstSecretFilesChunkSize is the size in bytes of the plaintext chunks of the encrypted files.
+	ConstSecretFilesChunkSize int = 64 * 1024
+
+	// ConstSecretFilesFormFieldsMaxSize is the maximum total size in bytes of the text fields of the add secret form with files.
+	ConstSecretFilesFormFieldsMaxSize int64 = 1024 * 1024
+
+	// ConstSecretFilesTransferTimeout is the time in seconds to upload or download the files of the secret
+	// (instead of the server read and write timeouts).
+	ConstSecretFilesTransferTimeout int = 300
+
+	// ConstSecretFilesDownloadTTL is the time in minutes to download the files of the unlocked secret in the same browser.
+	ConstSecretFilesDownloadTTL int = 15
+
+	// ConstRecipientTypeAge is the recipient type of the secret encrypted to the age public key.
+	ConstRecipientTypeAge string = "age"
+
+	// ConstRecipientTypeOpenPGP is the recipient type of the secret encrypted to the OpenPGP public key.
+	ConstRecipientTypeOpenPGP string = "openpgp"
+
+	/*
+		Access code constants.
+	*/
+
+	// ConstAccessCodePolicyTypeCharacters is the access code policy type for the random characters.
+	ConstAccessCodePolicyTypeCharacters string = "characters"
+
+	// ConstAccessCodePolicyTypePassphrase is the access code policy type for the diceware passphrases.
+	ConstAccessCodePolicyTypePassphrase string = "passphrase"
+
+	// ConstAccessCodePolicyMinLength is the minimum length of the generated access codes.
+	ConstAccessCodePolicyMinLength int = 6
+
+	// ConstAccessCodePolicyMaxLength is the maximum length of the generated access codes.
+	ConstAccessCodePolicyMaxLength int = 64
+
+	// ConstAccessCodePolicyMinWords is the minimum number of words of the generated passphrases.
+	ConstAccessCodePolicyMinWords int = 3
+
+	// ConstAccessCodePolicyMaxWords is the maximum number of words of the generated passphrases.
+	ConstAccessCodePolicyMaxWords int = 10
+
+	// ConstAccessCodePolicyWordSeparators is the allowed separators of the words of the generated passphrases.
+	ConstAccessCodePolicyWordSeparators string = "-._ "
+
+	// ConstAccessCodePolicyAmbiguousCharacters is the characters, which are easy to confuse with each other.
+	ConstAccessCodePolicyAmbiguousCharacters string = "0Oo1lI"
+
+	// ConstAccessCodeArgon2Memory is the Argon2id memory cost in KiB for deriving keys from the access codes.
+	ConstAccessCodeArgon2Memory uint32 = 64 * 1024
+
+	// ConstAccessCodeArgon2Time is the Argon2id number of iterations for deriving keys from the access codes.
+	ConstAccessCodeArgon2Time uint32 = 3
+
+	// ConstAccessCodeArgon2Threads is the Argon2id degree of parallelism for deriving keys from the access codes.
+	ConstAccessCodeArgon2Threads uint8 = 4
+
+	// ConstAccessCodeArgon2SaltLength is the length of the random Argon2id salt in bytes.
+	ConstAccessCodeArgon2SaltLength int = 16
+
 	/*
 		Form constants.
 	*/
@@ -55,9 +306,22 @@ const (
 	// ConstFormAddSecretValueMinLength is the minimum length of the secret value.
 	ConstFormAddSecretValueMinLength int = 1
 
+	// ConstFormAddSecretMaxViewsMax is the maximum number of views of the secret with limited views.
+	ConstFormAddSecretMaxViewsMax int = 100
+
+	// ConstFormAddSecretClientEncryptedValueMinLength is the minimum length of the secret value encrypted in the browser
+	// (12-byte IV and 16-byte authentication tag).
+	ConstFormAddSecretClientEncryptedValueMinLength int = 28
+
 	// ConstFormAddSecretAccessCodeMinLength is the minimum length of the secret access code.
 	ConstFormAddSecretAccessCodeMinLength int = 6
 
-	// ConstFormAddSecretAccessCodeMaxLength is the maximum length of the secret access code.
-	ConstFormAddSecretAccessCodeMaxLength int = 32
+	// ConstFormAddSecretAccessCodeMaxLength is the maximum length of the secret access code (fits the longest passphrase).
+	ConstFormAddSecretAccessCodeMaxLength int = 128
+
+	// ConstFormAddSecretRecipientPublicKeyMaxLength is the maximum length of the recipient public key (fits the armored RSA-4096 key).
+	ConstFormAddSecretRecipientPublicKeyMaxLength int = 16384
+
+	// ConstFormAddSecretAccessCodeMinStrength is the minimum estimated strength (entropy in bits) of the custom access code.
+	ConstFormAddSecretAccessCodeMinStrength float64 = 40
 )
@@ -0,0 +1,123 @@
+package database
+
+import "time"
+
+// SecretFile represents an encrypted file record of the secret. The encrypted file is stored on disk by its storage key.
+type SecretFile struct {
+	ID         int       `db:"id"`
+	SecretID   int       `db:"secret_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	Name       string    `db:"name"`
+	Size       int64     `db:"size"`
+	StorageKey string    `db:"storage_key"`
+	AccessCode string    `db:"access_code"`
+	DataKey    string    `db:"data_key"`
+}
+
+// QueryGetSecretFilesBySecretID returns all files of the given secret from the database.
+func (d *Database) QueryGetSecretFilesBySecretID(secretID int) (files []*SecretFile, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/file/getManyBySecretID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&files, string(query), secretID); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// QueryGetSecretFile returns the file by the key of its secret and its ID from the database, if the secret is active
+// at the given time or was consumed by its last view (the expired by the dashboard and wiped secrets are skipped).
+func (d *Database) QueryGetSecretFile(key string, id int, now time.Time) (file SecretFile, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/file/getOneBySecretKeyAndID.sql")
+	if err != nil {
+		return file, err
+	}
+
+	// Get the record by the secret key and its ID from the database.
+	if err := d.Connection.Get(&file, string(query), key, id, now); err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
+// QueryGetSecretFileStorageKeys returns the storage keys of all files from the database.
+func (d *Database) QueryGetSecretFileStorageKeys() (storageKeys []string, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/file/getManyStorageKeys.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&storageKeys, string(query)); err != nil {
+		return nil, err
+	}
+
+	return storageKeys, nil
+}
+
+// QueryGetSecretFilesAfterID returns a batch of files with the ID greater than the given one from the database.
+// The files are returned as secrets with the ID and the protection fields of the file record, so their data keys
+// are re-wrapped the same way as the data keys of the secrets protected by the access code.
+func (d *Database) QueryGetSecretFilesAfterID(id, limit int) (secrets []*Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/file/getManyAfterID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&secrets, string(query), id, limit); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// QueryRotateFileEncryptedFields updates the data keys of the given files in a single transaction.
+// The file is skipped, if its data key was changed since it was read. Returns the number of updated files.
+func (d *Database) QueryRotateFileEncryptedFields(rotations []*SecretRotation) (int64, error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/file/rotateEncryptedFieldsOneByID.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
+
+	// Update the records in the database (only the data keys are re-wrapped).
+	var updated int64
+	for _, r := range rotations {
+		result, err := tx.Exec(string(query), r.DataKey, r.Secret.ID, r.Secret.DataKey)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		updated += rows
+	}
+
+	// Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
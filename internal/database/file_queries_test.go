@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// addTestSecretFile adds a new secret with one file and the given maximum number of views to the test DB.
+// It returns the ID of the file.
+func addTestSecretFile(t *testing.T, d *Database, key string, maxViews int) int {
+	t.Helper()
+
+	now := time.Now()
+	secret := &Secret{
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(time.Hour),
+		AvailableAt:    now,
+		AccessCode:     "access-code-" + key,
+		Name:           "name-" + key,
+		Key:            key,
+		Value:          "value-" + key,
+		MaxViews:       maxViews,
+		RemainingViews: maxViews,
+		Files:          []*SecretFile{{CreatedAt: now, Name: "file.txt", Size: 1, StorageKey: "storage-key-" + key}},
+	}
+	if err := d.QueryAddSecret(secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, err := d.QueryGetSecretFilesBySecretID(secret.ID)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("unexpected files, got: %v (%v), want: %v", len(files), err, 1)
+	}
+
+	return files[0].ID
+}
+
+func TestQueryGetSecretFile(t *testing.T) {
+	d := newTestDatabase(t)
+
+	// Test getting the file of the secret, which was consumed by its last view
+	id := addTestSecretFile(t, d, "burn-after-reading", 1)
+
+	if _, err := d.QueryUnlockSecretByKey("burn-after-reading", time.Now(), func(s *Secret) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := d.QueryGetSecretFile("burn-after-reading", id, time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Test getting the file of the secret, which was expired from the dashboard
+	id = addTestSecretFile(t, d, "expired", 0)
+
+	if _, err := d.QueryGetSecretFile("expired", id, time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := d.QueryUpdateExpiresAtFieldByKey("expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := d.QueryGetSecretFile("expired", id, time.Now()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, sql.ErrNoRows)
+	}
+}
@@ -1,5 +1,12 @@
 package database
 
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+)
+
 // Migrate migrates the given DB schema from the embedded SQL file.
 func (d *Database) Migrate(schema string) error {
 	// Read the DB schema from the embedded SQL file.
@@ -13,3 +20,52 @@ func (d *Database) Migrate(schema string) error {
 
 	return nil
 }
+
+// MigrateVersions applies the embedded SQL migrations from the given folder, which are newer than the DB schema version.
+// The migrations are applied in order of their file names, and the DB schema version is stored in the 'user_version' pragma.
+func (d *Database) MigrateVersions(folder string) error {
+	// Read the migration files from the embedded folder.
+	files, err := fs.Glob(d.SQLQueries, path.Join(folder, "*.sql"))
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+
+	// Get the current DB schema version.
+	var version int
+	if err := d.Connection.Get(&version, "PRAGMA user_version"); err != nil {
+		return err
+	}
+
+	// Apply the migrations, which are newer than the current DB schema version.
+	for i := version; i < len(files); i++ {
+		// Read the migration from the embedded SQL file.
+		s, err := d.SQLQueries.ReadFile(files[i])
+		if err != nil {
+			return err
+		}
+
+		// Begin a new transaction.
+		tx, err := d.Connection.Beginx()
+		if err != nil {
+			return err
+		}
+
+		// Apply the migration and set the new DB schema version.
+		if _, err := tx.Exec(string(s)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", files[i], err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		// Commit the transaction.
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
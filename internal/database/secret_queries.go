@@ -1,41 +1,155 @@
 package database
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/secretium/secretium/internal/messages"
 )
 
+// ErrSecretIsExpired is returned when the secret is expired or was already consumed by another unlock.
+var ErrSecretIsExpired = errors.New(messages.ErrSecretIsExpired)
+
+// ErrSecretIsNotAvailable is returned when the scheduled secret is not available for unlocking yet.
+var ErrSecretIsNotAvailable = errors.New(messages.ErrSecretIsNotAvailable)
+
+// ErrSecretIsLocked is returned when the secret is locked after too many failed access code attempts.
+var ErrSecretIsLocked = errors.New(messages.ErrSecretIsLocked)
+
+// ErrSecretIsDestroyed is returned when the secret is destroyed after too many failed access code attempts.
+var ErrSecretIsDestroyed = errors.New(messages.ErrSecretIsDestroyed)
+
+// ErrSecretIsWiped is returned when the encrypted fields of the expired secret were wiped by the janitor.
+var ErrSecretIsWiped = errors.New(messages.ErrSecretIsWiped)
+
+// ErrSecretIsChanged is returned when the secret was changed or wiped since it was read for editing.
+var ErrSecretIsChanged = errors.New(messages.ErrSecretIsChanged)
+
+// ErrSecretIsNotUnique is returned when the key or access code of the new secret is already taken.
+var ErrSecretIsNotUnique = errors.New(messages.ErrSecretIsNotUnique)
+
 // Secret represents a secret record.
 type Secret struct {
-	ID                       int       `db:"id"`
-	CreatedAt                time.Time `db:"created_at"`
-	ExpiresAt                time.Time `db:"expires_at"`
-	AccessCode               string    `db:"access_code"`
-	Name                     string    `db:"name"`
-	Key                      string    `db:"key"`
-	Value                    string    `db:"value"`
-	IsExpireAfterFirstUnlock bool      `db:"is_expire_after_first_unlock"`
+	ID                       int        `db:"id"`
+	CreatedAt                time.Time  `db:"created_at"`
+	ExpiresAt                time.Time  `db:"expires_at"`
+	AccessCode               string     `db:"access_code"`
+	Name                     string     `db:"name"`
+	Key                      string     `db:"key"`
+	Value                    string     `db:"value"`
+	DataKey                  string     `db:"data_key"`
+	IsExpireAfterFirstUnlock bool       `db:"is_expire_after_first_unlock"`
+	IsClientEncrypted        bool       `db:"is_client_encrypted"`
+	IsAccessCodeProtected    bool       `db:"is_access_code_protected"`
+	SharesTotal              int        `db:"shares_total"`
+	SharesThreshold          int        `db:"shares_threshold"`
+	RecipientType            string     `db:"recipient_type"`
+	Recipient                string     `db:"recipient"`
+	MaxViews                 int        `db:"max_views"`
+	RemainingViews           int        `db:"remaining_views"`
+	WipedAt                  *time.Time `db:"wiped_at"`
+	RenewCount               int        `db:"renew_count"`
+	RenewedAt                *time.Time `db:"renewed_at"`
+	RenewedBy                string     `db:"renewed_by"`
+	AvailableAt              time.Time  `db:"available_at"`
+	Version                  int        `db:"version"`
+	UpdatedAt                *time.Time `db:"updated_at"`
+	UpdatedBy                string     `db:"updated_by"`
+	FailedAttempts           int        `db:"failed_attempts"`
+	LockedAt                 *time.Time `db:"locked_at"`
+
+	// Files are the encrypted files of the secret, which are added and listed separately from the record.
+	Files []*SecretFile `db:"-"`
 }
 
-// QueryAddSecret adds a new secret to the database.
-func (d *Database) QueryAddSecret(s *Secret) error {
-	// Create a query from the embedded SQL file.
+// SecretRotation represents the re-encrypted fields of a secret record.
+type SecretRotation struct {
+	Secret                     *Secret
+	AccessCode, Value, DataKey string
+}
+
+// QueryAddSecret adds a new secret with its shares (if the secret is split) and its files to the database in a single transaction.
+func (d *Database) QueryAddSecret(s *Secret, shares ...*SecretShare) error {
+	// Create queries from the embedded SQL files.
 	query, err := d.SQLQueries.ReadFile("sql_queries/secret/add.sql")
 	if err != nil {
 		return err
 	}
+	shareQuery, err := d.SQLQueries.ReadFile("sql_queries/share/add.sql")
+	if err != nil {
+		return err
+	}
+	fileQuery, err := d.SQLQueries.ReadFile("sql_queries/file/add.sql")
+	if err != nil {
+		return err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
 
 	// Add the record to the database.
-	_, err = d.Connection.Exec(
+	result, err := tx.Exec(
 		string(query),
 		s.CreatedAt, s.ExpiresAt,
-		s.AccessCode, s.Name, s.Key, s.Value,
-		s.IsExpireAfterFirstUnlock,
+		s.AccessCode, s.Name, s.Key, s.Value, s.DataKey,
+		s.IsExpireAfterFirstUnlock, s.IsClientEncrypted, s.IsAccessCodeProtected,
+		s.SharesTotal, s.SharesThreshold,
+		s.RecipientType, s.Recipient,
+		s.MaxViews, s.RemainingViews,
+		s.AvailableAt,
 	)
+	if err != nil {
+		return uniqueConstraintError(err)
+	}
+
+	// Get the ID of the new record.
+	id, err := result.LastInsertId()
 	if err != nil {
 		return err
 	}
+	s.ID = int(id)
 
-	return nil
+	// Add the shares of the record to the database.
+	for _, share := range shares {
+		share.SecretID = s.ID
+		if _, err := tx.Exec(string(shareQuery), share.SecretID, share.Number, share.Key, share.AccessCode, share.Value); err != nil {
+			return uniqueConstraintError(err)
+		}
+	}
+
+	// Add the files of the record to the database.
+	for _, file := range s.Files {
+		file.SecretID = s.ID
+		if _, err := tx.Exec(
+			string(fileQuery),
+			file.SecretID, file.CreatedAt, file.Name, file.Size, file.StorageKey, file.AccessCode, file.DataKey,
+		); err != nil {
+			return err
+		}
+	}
+
+	// Commit the transaction.
+	return tx.Commit()
+}
+
+// uniqueConstraintError returns the ErrSecretIsNotUnique error, if the given error violates the UNIQUE constraint.
+func uniqueConstraintError(err error) error {
+	// Check, if the key or access code violates the UNIQUE constraint.
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return ErrSecretIsNotUnique
+	}
+
+	return err
 }
 
 // QueryGetSecretByKey returns the secret by its key from the database.
@@ -54,6 +168,103 @@ func (d *Database) QueryGetSecretByKey(key string) (secret Secret, err error) {
 	return secret, nil
 }
 
+// QueryGetSecretByID returns the secret by its ID from the database.
+func (d *Database) QueryGetSecretByID(id int) (secret Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/getOneByID.sql")
+	if err != nil {
+		return secret, err
+	}
+
+	// Get the record by its ID from the database.
+	if err := d.Connection.Get(&secret, string(query), id); err != nil {
+		return secret, err
+	}
+
+	return secret, nil
+}
+
+// QueryUnlockSecretByKey gets the secret by its key and passes it to the unlock function, which verifies the access code
+// and decrypts the secret outside of any transaction. If the unlock function succeeds, one view of the secret is used
+// by a single conditional update (the secret is expired after the last view), which checks again, that the secret is
+// still active and not locked, so no more callers than the views can receive the unlocked secret.
+// Returns ErrSecretIsExpired, ErrSecretIsNotAvailable or ErrSecretIsLocked, if the secret is expired, is scheduled
+// for later or is locked after too many failed access code attempts.
+func (d *Database) QueryUnlockSecretByKey(key string, now time.Time, unlock func(s *Secret) error) (secret Secret, err error) {
+	// Create queries from the embedded SQL files.
+	getQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/getOneByKey.sql")
+	if err != nil {
+		return secret, err
+	}
+	useViewQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/useViewOneByKey.sql")
+	if err != nil {
+		return secret, err
+	}
+
+	// Get the record by its key from the database.
+	if err := d.Connection.Get(&secret, string(getQuery), key); err != nil {
+		return secret, err
+	}
+
+	// Check, if the secret can be unlocked.
+	if err := checkSecretUnlockable(&secret, now); err != nil {
+		return secret, err
+	}
+
+	// Unlock the secret.
+	if err := unlock(&secret); err != nil {
+		return secret, err
+	}
+
+	// Use one view of the record, if it is still active and not locked.
+	var remainingViews int
+	if err := d.Connection.Get(&remainingViews, string(useViewQuery), now, key); errors.Is(err, sql.ErrNoRows) {
+		// Get the record again to return the reason, why it cannot be unlocked anymore.
+		var current Secret
+		if err := d.Connection.Get(&current, string(getQuery), key); err != nil {
+			return secret, err
+		}
+		if err := checkSecretUnlockable(&current, now); err != nil {
+			return secret, err
+		}
+
+		return secret, ErrSecretIsExpired
+	} else if err != nil {
+		return secret, err
+	}
+
+	// Set the remaining views and the new expiration date after the last view.
+	if secret.MaxViews > 0 {
+		secret.RemainingViews = remainingViews
+		if secret.RemainingViews == 0 {
+			secret.ExpiresAt = now
+		}
+	}
+
+	return secret, nil
+}
+
+// checkSecretUnlockable returns ErrSecretIsExpired, ErrSecretIsNotAvailable or ErrSecretIsLocked, if the given secret
+// is expired, is scheduled for later or is locked after too many failed access code attempts.
+func checkSecretUnlockable(s *Secret, now time.Time) error {
+	// Check, if the secret is expired.
+	if !s.ExpiresAt.After(now) {
+		return ErrSecretIsExpired
+	}
+
+	// Check, if the secret is available for unlocking.
+	if s.AvailableAt.After(now) {
+		return ErrSecretIsNotAvailable
+	}
+
+	// Check, if the secret is locked after too many failed access code attempts.
+	if s.LockedAt != nil {
+		return ErrSecretIsLocked
+	}
+
+	return nil
+}
+
 // QueryUpdateExpiresAtFieldByKey updates the 'expires_at' field of the secret by its key in the database.
 func (d *Database) QueryUpdateExpiresAtFieldByKey(key string, expiredAt time.Time) error {
 	// Create a query from the embedded SQL file.
@@ -71,6 +282,165 @@ func (d *Database) QueryUpdateExpiresAtFieldByKey(key string, expiredAt time.Tim
 	return nil
 }
 
+// QueryRenewSecretByKey updates the 'expires_at' field of the secret by its key in the database, restores
+// the remaining views of the secret with limited views, and records the renewal with its date and author.
+// Returns ErrSecretIsWiped, if the secret was not found or its encrypted fields were wiped by the janitor.
+func (d *Database) QueryRenewSecretByKey(key string, expiresAt, renewedAt time.Time, renewedBy string) error {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/renewOneByKey.sql")
+	if err != nil {
+		return err
+	}
+
+	// Renew the record by its key in the database.
+	result, err := d.Connection.Exec(string(query), expiresAt, renewedAt, renewedBy, key)
+	if err != nil {
+		return err
+	}
+
+	// Check, if the record was renewed (the wiped records cannot be renewed).
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSecretIsWiped
+	}
+
+	return nil
+}
+
+// QueryEditSecret updates the name and encrypted fields of the given secret by its key in the database, and increases
+// its version in a single transaction. The current version of the secret is kept in its previous versions with the given
+// date and author of the edit. Returns ErrSecretIsChanged, if the version of the secret in the database is not the same
+// as the version of the given secret (the secret was edited by another request since it was read), or the secret was wiped.
+func (d *Database) QueryEditSecret(s *Secret, editedAt time.Time, editedBy string) error {
+	// Create queries from the embedded SQL files.
+	addVersionQuery, err := d.SQLQueries.ReadFile("sql_queries/version/addFromSecretByKey.sql")
+	if err != nil {
+		return err
+	}
+	editQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/editOneByKey.sql")
+	if err != nil {
+		return err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
+
+	// Keep the current version of the record.
+	result, err := tx.Exec(string(addVersionQuery), editedAt, editedBy, s.Key, s.Version)
+	if err != nil {
+		return err
+	}
+
+	// Check, if the current version of the record was kept by this transaction.
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSecretIsChanged
+	}
+
+	// Update the record by its key in the database.
+	if _, err := tx.Exec(
+		string(editQuery),
+		s.Name, s.AccessCode, s.Value, s.DataKey,
+		editedAt, editedBy,
+		s.Key, s.Version,
+	); err != nil {
+		return uniqueConstraintError(err)
+	}
+
+	// Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Set the new version of the secret.
+	s.Version++
+	s.UpdatedAt, s.UpdatedBy = &editedAt, editedBy
+
+	return nil
+}
+
+// QueryFailAttemptByKey counts one failed access code attempt of the secret by its key in the database, and locks
+// the secret, when the given limit of the failed attempts is reached (zero means unlimited attempts). The locked secret
+// is permanently deleted in the same transaction, if it should be destroyed. Returns the number of the failed attempts,
+// and ErrSecretIsLocked or ErrSecretIsDestroyed, if the secret was locked or destroyed.
+func (d *Database) QueryFailAttemptByKey(key string, limit int, destroy bool, now time.Time) (attempts int, err error) {
+	// Create queries from the embedded SQL files.
+	failQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/failAttemptOneByKey.sql")
+	if err != nil {
+		return 0, err
+	}
+	deleteQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/deleteOneByKey.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
+
+	// Count the failed attempt of the record.
+	var isLocked bool
+	if err := tx.QueryRowx(string(failQuery), limit, now, key).Scan(&attempts, &isLocked); err != nil {
+		return 0, err
+	}
+
+	// Delete the locked record, if it should be destroyed (the shares, versions and files are deleted by the triggers).
+	if isLocked && destroy {
+		if _, err := tx.Exec(string(deleteQuery), key); err != nil {
+			return attempts, err
+		}
+	}
+
+	// Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return attempts, err
+	}
+
+	switch {
+	case isLocked && destroy:
+		return attempts, ErrSecretIsDestroyed
+	case isLocked:
+		return attempts, ErrSecretIsLocked
+	}
+
+	return attempts, nil
+}
+
+// QueryResetFailedAttemptsByKey resets the failed access code attempts of the secret by its key in the database,
+// and unlocks the secret, if it was locked after them.
+func (d *Database) QueryResetFailedAttemptsByKey(key string) error {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/resetFailedAttemptsOneByKey.sql")
+	if err != nil {
+		return err
+	}
+
+	// Reset the failed attempts of the record by its key in the database.
+	_, err = d.Connection.Exec(string(query), key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // QueryUpdateAccessCodeFieldByKey updates the 'access_code' field of the secret by its key in the database.
 func (d *Database) QueryUpdateAccessCodeFieldByKey(key, accessCode string) error {
 	// Create a query from the embedded SQL file.
@@ -88,6 +458,73 @@ func (d *Database) QueryUpdateAccessCodeFieldByKey(key, accessCode string) error
 	return nil
 }
 
+// QueryGetSecretsAfterID returns a batch of secrets with the ID greater than the given one from the database.
+func (d *Database) QueryGetSecretsAfterID(id, limit int) (secrets []*Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/getManyAfterID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&secrets, string(query), id, limit); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// QueryRotateEncryptedFields updates the encrypted fields of the given secrets in a single transaction.
+// The secret is skipped, if its encrypted fields were changed since they were read. Returns the number of updated secrets.
+func (d *Database) QueryRotateEncryptedFields(rotations []*SecretRotation) (int64, error) {
+	return d.rotateEncryptedFields("sql_queries/secret/rotateEncryptedFieldsOneByID.sql", rotations)
+}
+
+// rotateEncryptedFields updates the encrypted fields of the given records with the given embedded SQL file
+// in a single transaction. Returns the number of updated records.
+func (d *Database) rotateEncryptedFields(file string, rotations []*SecretRotation) (int64, error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
+
+	// Update the records in the database.
+	var updated int64
+	for _, r := range rotations {
+		result, err := tx.Exec(
+			string(query),
+			r.AccessCode, r.Value, r.DataKey,
+			r.Secret.ID, r.Secret.AccessCode, r.Secret.Value, r.Secret.DataKey,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		updated += rows
+	}
+
+	// Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
 // QueryDeleteSecretByKey deletes a secret by its key from the database.
 func (d *Database) QueryDeleteSecretByKey(key string) error {
 	// Create a query from the embedded SQL file.
@@ -105,6 +542,97 @@ func (d *Database) QueryDeleteSecretByKey(key string) error {
 	return nil
 }
 
+// QueryGetSecretsByKeys returns the secrets (without their encrypted fields) by their keys from the database.
+func (d *Database) QueryGetSecretsByKeys(keys []string) (secrets []*Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/getManyByKeys.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the keys to the JSON array for the query.
+	keysJSON, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records by their keys from the database.
+	if err := d.Connection.Select(&secrets, string(query), string(keysJSON)); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// QueryExpireSecretsByKeys updates the 'expires_at' field of the active secrets by their keys in the database.
+// Returns the keys of the expired secrets (the secrets, which are already expired, are skipped).
+func (d *Database) QueryExpireSecretsByKeys(keys []string, expiredAt time.Time) (expiredKeys []string, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/expireManyByKeys.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the keys to the JSON array for the query.
+	keysJSON, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expire the records by their keys in the database.
+	if err := d.Connection.Select(&expiredKeys, string(query), expiredAt, string(keysJSON)); err != nil {
+		return nil, err
+	}
+
+	return expiredKeys, nil
+}
+
+// QueryRenewSecretsByKeys renews the secrets by their keys in the database, like the QueryRenewSecretByKey function.
+// Returns the keys of the renewed secrets (the wiped secrets are skipped).
+func (d *Database) QueryRenewSecretsByKeys(keys []string, expiresAt, renewedAt time.Time, renewedBy string) (renewedKeys []string, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/renewManyByKeys.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the keys to the JSON array for the query.
+	keysJSON, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Renew the records by their keys in the database.
+	if err := d.Connection.Select(&renewedKeys, string(query), expiresAt, renewedAt, renewedBy, string(keysJSON)); err != nil {
+		return nil, err
+	}
+
+	return renewedKeys, nil
+}
+
+// QueryDeleteSecretsByKeys deletes the secrets (and their shares, versions and files) by their keys from the database.
+// Returns the keys of the deleted secrets.
+func (d *Database) QueryDeleteSecretsByKeys(keys []string) (deletedKeys []string, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/deleteManyByKeys.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the keys to the JSON array for the query.
+	keysJSON, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete the records by their keys from the database (the shares, versions and files are deleted by the triggers).
+	if err := d.Connection.Select(&deletedKeys, string(query), string(keysJSON)); err != nil {
+		return nil, err
+	}
+
+	return deletedKeys, nil
+}
+
 // QueryGetActiveSecrets returns the active secrets from the database.
 func (d *Database) QueryGetActiveSecrets() (secrets []*Secret, err error) {
 	// Create a query from the embedded SQL file.
@@ -121,6 +649,22 @@ func (d *Database) QueryGetActiveSecrets() (secrets []*Secret, err error) {
 	return secrets, nil
 }
 
+// QueryGetScheduledSecrets returns the scheduled secrets, which are not available yet, from the database.
+func (d *Database) QueryGetScheduledSecrets() (secrets []*Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/getManyScheduled.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&secrets, string(query)); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
 // QueryGetExpiredSecrets returns the expired secrets from the database.
 func (d *Database) QueryGetExpiredSecrets() (secrets []*Secret, err error) {
 	// Create a query from the embedded SQL file.
@@ -136,3 +680,78 @@ func (d *Database) QueryGetExpiredSecrets() (secrets []*Secret, err error) {
 
 	return secrets, nil
 }
+
+// QueryDeleteSecretsExpiredBefore permanently deletes the secrets (and their shares, versions and files), which expired before the given date,
+// from the database. Returns the keys of the deleted secrets.
+func (d *Database) QueryDeleteSecretsExpiredBefore(before time.Time) (keys []string, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/secret/deleteManyExpiredBefore.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete the records from the database (the shares, versions and files are deleted by the triggers).
+	if err := d.Connection.Select(&keys, string(query), before); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// QueryWipeSecretsExpiredBefore wipes the encrypted fields (and deletes the shares, versions and files) of the secrets, which expired
+// before the given date, in a single transaction. The metadata of the secrets is kept. Returns the keys of the wiped secrets.
+func (d *Database) QueryWipeSecretsExpiredBefore(before, now time.Time) (keys []string, err error) {
+	// Create the queries from the embedded SQL files.
+	deleteSharesQuery, err := d.SQLQueries.ReadFile("sql_queries/share/deleteManyBySecretExpiredBefore.sql")
+	if err != nil {
+		return nil, err
+	}
+	deleteVersionsQuery, err := d.SQLQueries.ReadFile("sql_queries/version/deleteManyBySecretExpiredBefore.sql")
+	if err != nil {
+		return nil, err
+	}
+	deleteFilesQuery, err := d.SQLQueries.ReadFile("sql_queries/file/deleteManyBySecretExpiredBefore.sql")
+	if err != nil {
+		return nil, err
+	}
+	wipeQuery, err := d.SQLQueries.ReadFile("sql_queries/secret/wipeManyExpiredBefore.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Begin a new transaction.
+	tx, err := d.Connection.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure to roll back the transaction, if it was not committed.
+	defer func() { _ = tx.Rollback() }()
+
+	// Delete the shares of the records, which are not wiped yet.
+	if _, err := tx.Exec(string(deleteSharesQuery), before); err != nil {
+		return nil, err
+	}
+
+	// Delete the previous versions of the records, which are not wiped yet.
+	if _, err := tx.Exec(string(deleteVersionsQuery), before); err != nil {
+		return nil, err
+	}
+
+	// Delete the files of the records, which are not wiped yet (the encrypted files on disk are removed by the application).
+	if _, err := tx.Exec(string(deleteFilesQuery), before); err != nil {
+		return nil, err
+	}
+
+	// Wipe the records in the database.
+	if err := tx.Select(&keys, string(wipeQuery), now, before); err != nil {
+		return nil, err
+	}
+
+	// Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,238 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// newTestDatabase returns a new instance of Database with the migrated DB schema in the temporary SQLite file.
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	// Connect to the temporary SQLite DB.
+	connection, err := sqlx.Connect("sqlite3", filepath.Join(t.TempDir(), "db.sqlite3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+
+	d := &Database{Connection: connection, SQLQueries: sqlQueries}
+
+	// Migrate the DB schema.
+	if err := d.Migrate("sql_queries/init.sql"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.MigrateVersions("sql_queries/migrations"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return d
+}
+
+// addTestSecret adds a new secret with the given key and maximum number of views to the test DB.
+func addTestSecret(t *testing.T, d *Database, key string, maxViews int) {
+	t.Helper()
+
+	now := time.Now()
+	if err := d.QueryAddSecret(&Secret{
+		CreatedAt:                now,
+		ExpiresAt:                now.Add(time.Hour),
+		AvailableAt:              now,
+		AccessCode:               "access-code-" + key,
+		Name:                     "name-" + key,
+		Key:                      key,
+		Value:                    "value-" + key,
+		IsExpireAfterFirstUnlock: maxViews == 1,
+		MaxViews:                 maxViews,
+		RemainingViews:           maxViews,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// unlockTestSecretConcurrently unlocks the secret by its key from the given number of goroutines at once,
+// and returns the number of the successful unlocks. Each unlock waits for the others to read the secret first.
+func unlockTestSecretConcurrently(d *Database, key string, callers int) (unlocked int) {
+	var mu sync.Mutex
+	var ready, done sync.WaitGroup
+	ready.Add(callers)
+	done.Add(callers)
+
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+
+			// Unlock the secret after all callers have read it.
+			var once sync.Once
+			secret, err := d.QueryUnlockSecretByKey(key, time.Now(), func(s *Secret) error {
+				once.Do(ready.Done)
+				ready.Wait()
+				return nil
+			})
+			once.Do(ready.Done)
+
+			if err == nil && secret.Value == "value-"+key {
+				mu.Lock()
+				unlocked++
+				mu.Unlock()
+			}
+		}()
+	}
+	done.Wait()
+
+	return unlocked
+}
+
+func TestQueryUnlockSecretByKeyBurnAfterReading(t *testing.T) {
+	d := newTestDatabase(t)
+	addTestSecret(t, d, "burn-after-reading", 1)
+
+	// Test unlocking the burn-after-reading secret twice at once
+	if unlocked := unlockTestSecretConcurrently(d, "burn-after-reading", 2); unlocked != 1 {
+		t.Errorf("unexpected number of unlocks, got: %v, want: %v", unlocked, 1)
+	}
+
+	// Test unlocking the consumed secret
+	if _, err := d.QueryUnlockSecretByKey("burn-after-reading", time.Now(), func(s *Secret) error { return nil }); !errors.Is(err, ErrSecretIsExpired) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, ErrSecretIsExpired)
+	}
+}
+
+func TestQueryUnlockSecretByKeyMaxViews(t *testing.T) {
+	d := newTestDatabase(t)
+	addTestSecret(t, d, "max-views", 3)
+
+	// Test unlocking the secret with the limited views one by one
+	for view := 1; view <= 3; view++ {
+		secret, err := d.QueryUnlockSecretByKey("max-views", time.Now(), func(s *Secret) error { return nil })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if secret.RemainingViews != 3-view {
+			t.Errorf("unexpected remaining views, got: %v, want: %v", secret.RemainingViews, 3-view)
+		}
+	}
+
+	// Test unlocking the secret after the last view
+	if _, err := d.QueryUnlockSecretByKey("max-views", time.Now(), func(s *Secret) error { return nil }); !errors.Is(err, ErrSecretIsExpired) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, ErrSecretIsExpired)
+	}
+
+	// Test unlocking the secret with the limited views from more callers than the views at once
+	addTestSecret(t, d, "concurrent-views", 2)
+
+	if unlocked := unlockTestSecretConcurrently(d, "concurrent-views", 5); unlocked != 2 {
+		t.Errorf("unexpected number of unlocks, got: %v, want: %v", unlocked, 2)
+	}
+
+	secret, err := d.QueryGetSecretByKey("concurrent-views")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secret.RemainingViews != 0 {
+		t.Errorf("unexpected remaining views, got: %v, want: %v", secret.RemainingViews, 0)
+	}
+}
+
+func TestQueryFailAttemptByKey(t *testing.T) {
+	d := newTestDatabase(t)
+	addTestSecret(t, d, "lock", 0)
+
+	// Test counting the failed attempts before the limit
+	for attempt := 1; attempt < 3; attempt++ {
+		attempts, err := d.QueryFailAttemptByKey("lock", 3, false, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if attempts != attempt {
+			t.Errorf("unexpected number of attempts, got: %v, want: %v", attempts, attempt)
+		}
+	}
+
+	// Test locking the secret at the limit
+	if attempts, err := d.QueryFailAttemptByKey("lock", 3, false, time.Now()); !errors.Is(err, ErrSecretIsLocked) || attempts != 3 {
+		t.Errorf("unexpected result, got: %v (%v), want: %v (%v)", attempts, err, 3, ErrSecretIsLocked)
+	}
+
+	if _, err := d.QueryUnlockSecretByKey("lock", time.Now(), func(s *Secret) error { return nil }); !errors.Is(err, ErrSecretIsLocked) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, ErrSecretIsLocked)
+	}
+
+	// Test resetting the failed attempts of the locked secret from the dashboard
+	if err := d.QueryResetFailedAttemptsByKey("lock"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secret, err := d.QueryGetSecretByKey("lock")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secret.FailedAttempts != 0 || secret.LockedAt != nil {
+		t.Errorf("unexpected failed attempts, got: %v (locked at %v), want: %v (locked at %v)", secret.FailedAttempts, secret.LockedAt, 0, nil)
+	}
+
+	if _, err := d.QueryUnlockSecretByKey("lock", time.Now(), func(s *Secret) error { return nil }); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Test counting the failed attempts without the limit
+	addTestSecret(t, d, "unlimited", 0)
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		if _, err := d.QueryFailAttemptByKey("unlimited", 0, true, time.Now()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Test destroying the secret at the limit
+	addTestSecret(t, d, "destroy", 0)
+
+	if _, err := d.QueryFailAttemptByKey("destroy", 2, true, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts, err := d.QueryFailAttemptByKey("destroy", 2, true, time.Now()); !errors.Is(err, ErrSecretIsDestroyed) || attempts != 2 {
+		t.Errorf("unexpected result, got: %v (%v), want: %v (%v)", attempts, err, 2, ErrSecretIsDestroyed)
+	}
+
+	if _, err := d.QueryGetSecretByKey("destroy"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestQueryRenewSecretByKey(t *testing.T) {
+	d := newTestDatabase(t)
+	addTestSecret(t, d, "renew", 2)
+
+	// Use one view of the secret
+	if _, err := d.QueryUnlockSecretByKey("renew", time.Now(), func(s *Secret) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Test renewing the secret one by one and in bulk without restoring the used views
+	if err := d.QueryRenewSecretByKey("renew", time.Now().Add(2*time.Hour), time.Now(), "admin"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := d.QueryRenewSecretsByKeys([]string{"renew"}, time.Now().Add(3*time.Hour), time.Now(), "admin"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secret, err := d.QueryGetSecretByKey("renew")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secret.RemainingViews != 1 || secret.RenewCount != 2 {
+		t.Errorf("unexpected renewed secret, got: %v views (%v renewals), want: %v views (%v renewals)", secret.RemainingViews, secret.RenewCount, 1, 2)
+	}
+}
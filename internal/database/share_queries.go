@@ -0,0 +1,43 @@
+package database
+
+// SecretShare represents a share record of the split secret.
+type SecretShare struct {
+	ID         int    `db:"id"`
+	SecretID   int    `db:"secret_id"`
+	Number     int    `db:"number"`
+	Key        string `db:"key"`
+	AccessCode string `db:"access_code"`
+	Value      string `db:"value"`
+}
+
+// QueryGetSecretShareByKey returns the share by its key from the database.
+func (d *Database) QueryGetSecretShareByKey(key string) (share SecretShare, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/share/getOneByKey.sql")
+	if err != nil {
+		return share, err
+	}
+
+	// Get the record by its key from the database.
+	if err := d.Connection.Get(&share, string(query), key); err != nil {
+		return share, err
+	}
+
+	return share, nil
+}
+
+// QueryGetSecretSharesBySecretID returns all shares of the secret by its ID from the database.
+func (d *Database) QueryGetSecretSharesBySecretID(secretID int) (shares []*SecretShare, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/share/getManyBySecretID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&shares, string(query), secretID); err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
@@ -0,0 +1,11 @@
+-- Add a new encrypted file of the secret.
+INSERT INTO `secret_sharer_files` (
+        `secret_id`,
+        `created_at`,
+        `name`,
+        `size`,
+        `storage_key`,
+        `access_code`,
+        `data_key`
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
\ No newline at end of file
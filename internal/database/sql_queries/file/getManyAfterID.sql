@@ -0,0 +1,12 @@
+-- Get a batch of file records after the given ID (the data key of each file is protected like the data key
+-- of the secret protected by the access code, so only its outer layer is re-wrapped).
+SELECT `id`,
+    `access_code`,
+    '' AS `value`,
+    `data_key`,
+    1 AS `is_access_code_protected`,
+    0 AS `shares_total`
+FROM `secret_sharer_files`
+WHERE `id` > $1
+ORDER BY `id` ASC
+LIMIT $2
\ No newline at end of file
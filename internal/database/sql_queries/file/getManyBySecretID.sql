@@ -0,0 +1,12 @@
+-- Get all files of the given secret.
+SELECT `id`,
+    `secret_id`,
+    `created_at`,
+    `name`,
+    `size`,
+    `storage_key`,
+    `access_code`,
+    `data_key`
+FROM `secret_sharer_files`
+WHERE `secret_id` = $1
+ORDER BY `id` ASC
\ No newline at end of file
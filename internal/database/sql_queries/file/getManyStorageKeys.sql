@@ -0,0 +1,3 @@
+-- Get the storage keys of all files.
+SELECT `storage_key`
+FROM `secret_sharer_files`
\ No newline at end of file
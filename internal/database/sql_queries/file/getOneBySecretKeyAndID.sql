@@ -0,0 +1,22 @@
+-- Get one file by the key of its secret and the given ID, if the secret is still active or was consumed by its last view
+-- (the files of the expired by the dashboard or wiped secrets cannot be downloaded with the earlier download grants).
+SELECT `f`.`id`,
+    `f`.`secret_id`,
+    `f`.`created_at`,
+    `f`.`name`,
+    `f`.`size`,
+    `f`.`storage_key`,
+    `f`.`access_code`,
+    `f`.`data_key`
+FROM `secret_sharer_files` AS `f`
+    JOIN `secret_sharer_data` AS `s` ON `s`.`id` = `f`.`secret_id`
+WHERE `s`.`key` = $1
+    AND `f`.`id` = $2
+    AND `s`.`wiped_at` IS NULL
+    AND (
+        `s`.`expires_at` > $3
+        OR (
+            `s`.`max_views` > 0
+            AND `s`.`remaining_views` = 0
+        )
+    )
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Update one file's data key by the given ID, if it was not changed since it was read
+-- (the access code and the encrypted file are never changed by the rotation).
+UPDATE `secret_sharer_files`
+SET `data_key` = $1
+WHERE `id` = $2
+    AND `data_key` = $3
\ No newline at end of file
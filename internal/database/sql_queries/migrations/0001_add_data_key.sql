@@ -0,0 +1,3 @@
+-- Add the wrapped data key of the secret value.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `data_key` text NOT NULL DEFAULT ''
\ No newline at end of file
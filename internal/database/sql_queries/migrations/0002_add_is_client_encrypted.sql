@@ -0,0 +1,3 @@
+-- Add the zero-knowledge mode flag of the secret value.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `is_client_encrypted` boolean NOT NULL DEFAULT false
\ No newline at end of file
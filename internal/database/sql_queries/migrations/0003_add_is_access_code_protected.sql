@@ -0,0 +1,3 @@
+-- Add the flag of the secret, which value is protected by a key derived from the access code.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `is_access_code_protected` boolean NOT NULL DEFAULT false
\ No newline at end of file
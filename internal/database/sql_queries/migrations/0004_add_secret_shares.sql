@@ -0,0 +1,23 @@
+-- Add the M-of-N split secrets with their shares.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `shares_total` integer NOT NULL DEFAULT 0;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `shares_threshold` integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS `secret_sharer_shares` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `secret_id` integer NOT NULL,
+    `number` integer NOT NULL,
+    `key` varchar(16) NOT NULL UNIQUE,
+    `access_code` text NOT NULL,
+    `value` text NOT NULL
+);
+
+-- Delete the shares together with their secret.
+CREATE TRIGGER IF NOT EXISTS `delete_secret_shares`
+AFTER DELETE ON `secret_sharer_data`
+BEGIN
+    DELETE FROM `secret_sharer_shares`
+    WHERE `secret_id` = OLD.`id`;
+END
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Add the secrets encrypted to the age or OpenPGP public key of the recipient.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `recipient_type` varchar(16) NOT NULL DEFAULT '';
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `recipient` text NOT NULL DEFAULT ''
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Add the limit of views of the secret (zero means unlimited views).
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `max_views` integer NOT NULL DEFAULT 0;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `remaining_views` integer NOT NULL DEFAULT 0;
+
+-- Convert the secrets, which expire after first unlock, to the secrets with one view.
+UPDATE `secret_sharer_data`
+SET `max_views` = 1,
+    `remaining_views` = 1
+WHERE `is_expire_after_first_unlock` = 1
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Add the date, when the encrypted fields of the expired secret were wiped by the janitor.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `wiped_at` datetime
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Add the number, the date and the author of the renewals of the secret.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `renew_count` integer NOT NULL DEFAULT 0;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `renewed_at` datetime;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `renewed_by` varchar(16) NOT NULL DEFAULT ''
\ No newline at end of file
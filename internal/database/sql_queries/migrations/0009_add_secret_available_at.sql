@@ -0,0 +1,7 @@
+-- Add the date, when the secret becomes available for unlocking ("not before").
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `available_at` datetime NOT NULL DEFAULT '1970-01-01 00:00:00+00:00';
+
+-- Make the existing secrets available since their creation.
+UPDATE `secret_sharer_data`
+SET `available_at` = `created_at`
\ No newline at end of file
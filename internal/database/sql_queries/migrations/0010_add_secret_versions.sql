@@ -0,0 +1,31 @@
+-- Add the version of the secret with the history of its previous versions.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `updated_at` datetime;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `updated_by` varchar(16) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS `secret_sharer_versions` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `secret_id` integer NOT NULL,
+    `version` integer NOT NULL,
+    `created_at` datetime NOT NULL,
+    `replaced_at` datetime NOT NULL,
+    `replaced_by` varchar(16) NOT NULL DEFAULT '',
+    `name` varchar(32) NOT NULL,
+    `access_code` text NOT NULL,
+    `value` text NOT NULL,
+    `data_key` text NOT NULL DEFAULT '',
+    UNIQUE (`secret_id`, `version`)
+);
+
+-- Delete the versions together with their secret.
+CREATE TRIGGER IF NOT EXISTS `delete_secret_versions`
+AFTER DELETE ON `secret_sharer_data`
+BEGIN
+    DELETE FROM `secret_sharer_versions`
+    WHERE `secret_id` = OLD.`id`;
+END
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Add the number of the failed access code attempts of the secret and the date, when it was locked after them.
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `failed_attempts` integer NOT NULL DEFAULT 0;
+
+ALTER TABLE `secret_sharer_data`
+ADD COLUMN `locked_at` datetime
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Add the encrypted files of the secrets, which are stored on disk by their storage keys.
+CREATE TABLE IF NOT EXISTS `secret_sharer_files` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `secret_id` integer NOT NULL,
+    `created_at` datetime NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `size` integer NOT NULL,
+    `storage_key` varchar(32) NOT NULL UNIQUE,
+    `access_code` text NOT NULL DEFAULT '',
+    `data_key` text NOT NULL
+);
+
+-- Delete the files together with their secret (the encrypted files on disk are removed by the application).
+CREATE TRIGGER IF NOT EXISTS `delete_secret_files`
+AFTER DELETE ON `secret_sharer_data`
+BEGIN
+    DELETE FROM `secret_sharer_files`
+    WHERE `secret_id` = OLD.`id`;
+END
\ No newline at end of file
@@ -6,6 +6,16 @@ INSERT INTO `secret_sharer_data` (
         `name`,
         `key`,
         `value`,
-        `is_expire_after_first_unlock`
+        `data_key`,
+        `is_expire_after_first_unlock`,
+        `is_client_encrypted`,
+        `is_access_code_protected`,
+        `shares_total`,
+        `shares_threshold`,
+        `recipient_type`,
+        `recipient`,
+        `max_views`,
+        `remaining_views`,
+        `available_at`
     )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
\ No newline at end of file
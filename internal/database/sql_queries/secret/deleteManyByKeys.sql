@@ -0,0 +1,4 @@
+-- Delete the records by the given keys (a JSON array of the keys).
+DELETE FROM `secret_sharer_data`
+WHERE `key` IN (SELECT `value` FROM json_each($1))
+RETURNING `key`
\ No newline at end of file
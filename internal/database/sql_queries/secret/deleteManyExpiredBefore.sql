@@ -0,0 +1,4 @@
+-- Delete all records expired before the given date.
+DELETE FROM `secret_sharer_data`
+WHERE `expires_at` <= $1
+RETURNING `key`
\ No newline at end of file
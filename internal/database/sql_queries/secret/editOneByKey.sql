@@ -0,0 +1,11 @@
+-- Update one secret's name and encrypted fields by the given key, and increase its version.
+UPDATE `secret_sharer_data`
+SET `name` = $1,
+    `access_code` = $2,
+    `value` = $3,
+    `data_key` = $4,
+    `version` = `version` + 1,
+    `updated_at` = $5,
+    `updated_by` = $6
+WHERE `key` = $7
+    AND `version` = $8
\ No newline at end of file
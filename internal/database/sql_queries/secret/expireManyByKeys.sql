@@ -0,0 +1,6 @@
+-- Expire the active records by the given keys (a JSON array of the keys).
+UPDATE `secret_sharer_data`
+SET `expires_at` = $1
+WHERE `key` IN (SELECT `value` FROM json_each($2))
+    AND `expires_at` > $1
+RETURNING `key`
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Count one failed access code attempt of the secret by the given key, and lock it, when the given limit is reached (zero means unlimited).
+UPDATE `secret_sharer_data`
+SET `failed_attempts` = `failed_attempts` + 1,
+    `locked_at` = CASE
+        WHEN $1 > 0
+        AND `failed_attempts` + 1 >= $1 THEN COALESCE(`locked_at`, $2)
+        ELSE `locked_at`
+    END
+WHERE `key` = $3
+RETURNING `failed_attempts`,
+    `locked_at` IS NOT NULL AS `is_locked`
\ No newline at end of file
@@ -4,7 +4,13 @@ SELECT `id`,
     `expires_at`,
     `name`,
     `key`,
-    `is_expire_after_first_unlock`
+    `is_expire_after_first_unlock`,
+    `max_views`,
+    `remaining_views`,
+    `available_at`,
+    `failed_attempts`,
+    `locked_at`
 FROM `secret_sharer_data`
 WHERE `expires_at` > datetime('now', 'localtime')
+    AND `available_at` <= datetime('now', 'localtime')
 ORDER BY `created_at` DESC
\ No newline at end of file
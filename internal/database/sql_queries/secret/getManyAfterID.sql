@@ -0,0 +1,13 @@
+-- Get a batch of records after the given ID.
+SELECT `id`,
+    `access_code`,
+    `key`,
+    `value`,
+    `data_key`,
+    `is_access_code_protected`,
+    `shares_total`
+FROM `secret_sharer_data`
+WHERE `id` > $1
+    AND `wiped_at` IS NULL
+ORDER BY `id` ASC
+LIMIT $2
\ No newline at end of file
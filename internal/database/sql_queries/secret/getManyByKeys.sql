@@ -0,0 +1,9 @@
+-- Get the records by the given keys (a JSON array of the keys).
+SELECT `id`,
+    `created_at`,
+    `expires_at`,
+    `name`,
+    `key`,
+    `wiped_at`
+FROM `secret_sharer_data`
+WHERE `key` IN (SELECT `value` FROM json_each($1))
\ No newline at end of file
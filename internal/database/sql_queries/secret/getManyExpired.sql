@@ -3,7 +3,8 @@ SELECT `id`,
     `created_at`,
     `expires_at`,
     `name`,
-    `key`
+    `key`,
+    `wiped_at`
 FROM `secret_sharer_data`
 WHERE `expires_at` <= datetime('now', 'localtime')
 ORDER BY `created_at` DESC
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Get all scheduled records, which are not available yet.
+SELECT `id`,
+    `created_at`,
+    `expires_at`,
+    `name`,
+    `key`,
+    `max_views`,
+    `remaining_views`,
+    `available_at`
+FROM `secret_sharer_data`
+WHERE `available_at` > datetime('now', 'localtime')
+    AND `expires_at` > datetime('now', 'localtime')
+ORDER BY `available_at` ASC
\ No newline at end of file
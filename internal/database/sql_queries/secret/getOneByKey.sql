@@ -1,10 +1,29 @@
 -- Get one secret by the given key.
-SELECT `created_at`,
+SELECT `id`,
+    `created_at`,
     `expires_at`,
     `access_code`,
     `name`,
     `key`,
     `value`,
-    `is_expire_after_first_unlock`
+    `data_key`,
+    `is_expire_after_first_unlock`,
+    `is_client_encrypted`,
+    `is_access_code_protected`,
+    `shares_total`,
+    `shares_threshold`,
+    `recipient_type`,
+    `recipient`,
+    `max_views`,
+    `remaining_views`,
+    `renew_count`,
+    `renewed_at`,
+    `renewed_by`,
+    `available_at`,
+    `version`,
+    `updated_at`,
+    `updated_by`,
+    `failed_attempts`,
+    `locked_at`
 FROM `secret_sharer_data`
 WHERE `key` = $1
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Renew the records by the given keys (a JSON array of the keys, only their expiration is extended, the used views are kept, the wiped secrets are skipped).
+UPDATE `secret_sharer_data`
+SET `expires_at` = $1,
+    `renewed_at` = $2,
+    `renewed_by` = $3,
+    `renew_count` = `renew_count` + 1
+WHERE `key` IN (SELECT `value` FROM json_each($4))
+    AND `wiped_at` IS NULL
+RETURNING `key`
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Renew one secret by the given key (only its expiration is extended, the used views are kept, the wiped secrets are skipped).
+UPDATE `secret_sharer_data`
+SET `expires_at` = $1,
+    `renewed_at` = $2,
+    `renewed_by` = $3,
+    `renew_count` = `renew_count` + 1
+WHERE `key` = $4
+    AND `wiped_at` IS NULL
\ No newline at end of file
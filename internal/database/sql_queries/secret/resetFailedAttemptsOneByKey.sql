@@ -0,0 +1,5 @@
+-- Reset the failed access code attempts of one secret by the given key, and unlock it.
+UPDATE `secret_sharer_data`
+SET `failed_attempts` = 0,
+    `locked_at` = NULL
+WHERE `key` = $1
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Use one view of the unlocked secret by the given key, if it has limited views (the secret is expired after the last view).
+-- The secret must be still active and not locked, so no more callers than the views can use them.
+UPDATE `secret_sharer_data`
+SET `remaining_views` = CASE
+        WHEN `max_views` > 0 THEN `remaining_views` - 1
+        ELSE `remaining_views`
+    END,
+    `expires_at` = CASE
+        WHEN `max_views` > 0
+        AND `remaining_views` = 1 THEN $1
+        ELSE `expires_at`
+    END
+WHERE `key` = $2
+    AND `expires_at` > $1
+    AND `locked_at` IS NULL
+    AND (
+        `max_views` = 0
+        OR `remaining_views` > 0
+    )
+RETURNING `remaining_views`
\ No newline at end of file
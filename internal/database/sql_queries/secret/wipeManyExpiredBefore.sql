@@ -0,0 +1,9 @@
+-- Wipe the encrypted fields of all records expired before the given date (the metadata is kept).
+UPDATE `secret_sharer_data`
+SET `access_code` = 'wiped:' || `key`,
+    `value` = '',
+    `data_key` = '',
+    `wiped_at` = $1
+WHERE `expires_at` <= $2
+    AND `wiped_at` IS NULL
+RETURNING `key`
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Add a new share of the split secret.
+INSERT INTO `secret_sharer_shares` (
+        `secret_id`,
+        `number`,
+        `key`,
+        `access_code`,
+        `value`
+    )
+VALUES ($1, $2, $3, $4, $5)
\ No newline at end of file
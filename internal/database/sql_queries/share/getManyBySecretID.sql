@@ -0,0 +1,8 @@
+-- Get all shares of the given secret.
+SELECT `id`,
+    `secret_id`,
+    `number`,
+    `key`
+FROM `secret_sharer_shares`
+WHERE `secret_id` = $1
+ORDER BY `number` ASC
\ No newline at end of file
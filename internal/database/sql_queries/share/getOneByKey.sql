@@ -0,0 +1,9 @@
+-- Get one share by the given key.
+SELECT `id`,
+    `secret_id`,
+    `number`,
+    `key`,
+    `access_code`,
+    `value`
+FROM `secret_sharer_shares`
+WHERE `key` = $1
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- Add the current version of the secret by the given key to its previous versions, if it was not changed since it was read.
+INSERT INTO `secret_sharer_versions` (
+        `secret_id`,
+        `version`,
+        `created_at`,
+        `replaced_at`,
+        `replaced_by`,
+        `name`,
+        `access_code`,
+        `value`,
+        `data_key`
+    )
+SELECT `id`,
+    `version`,
+    COALESCE(`updated_at`, `created_at`),
+    $1,
+    $2,
+    `name`,
+    `access_code`,
+    `value`,
+    `data_key`
+FROM `secret_sharer_data`
+WHERE `key` = $3
+    AND `version` = $4
+    AND `wiped_at` IS NULL
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Delete the versions of all records expired before the given date, which are not wiped yet.
+DELETE FROM `secret_sharer_versions`
+WHERE `secret_id` IN (
+        SELECT `id`
+        FROM `secret_sharer_data`
+        WHERE `expires_at` <= $1
+            AND `wiped_at` IS NULL
+    )
\ No newline at end of file
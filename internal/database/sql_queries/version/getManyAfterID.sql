@@ -0,0 +1,12 @@
+-- Get a batch of version records after the given ID (with the protection fields of their secrets).
+SELECT `v`.`id`,
+    `v`.`access_code`,
+    `v`.`value`,
+    `v`.`data_key`,
+    `s`.`is_access_code_protected`,
+    `s`.`shares_total`
+FROM `secret_sharer_versions` AS `v`
+    JOIN `secret_sharer_data` AS `s` ON `s`.`id` = `v`.`secret_id`
+WHERE `v`.`id` > $1
+ORDER BY `v`.`id` ASC
+LIMIT $2
\ No newline at end of file
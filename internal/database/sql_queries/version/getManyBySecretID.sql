@@ -0,0 +1,11 @@
+-- Get all previous versions of the given secret.
+SELECT `id`,
+    `secret_id`,
+    `version`,
+    `created_at`,
+    `replaced_at`,
+    `replaced_by`,
+    `name`
+FROM `secret_sharer_versions`
+WHERE `secret_id` = $1
+ORDER BY `version` DESC
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Get one previous version of the given secret by its number.
+SELECT `id`,
+    `secret_id`,
+    `version`,
+    `created_at`,
+    `replaced_at`,
+    `replaced_by`,
+    `name`,
+    `access_code`,
+    `value`,
+    `data_key`
+FROM `secret_sharer_versions`
+WHERE `secret_id` = $1
+    AND `version` = $2
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Update one version's encrypted fields by the given ID, if they were not changed since they were read.
+UPDATE `secret_sharer_versions`
+SET `access_code` = $1,
+    `value` = $2,
+    `data_key` = $3
+WHERE `id` = $4
+    AND `access_code` = $5
+    AND `value` = $6
+    AND `data_key` = $7
\ No newline at end of file
@@ -0,0 +1,74 @@
+package database
+
+import "time"
+
+// SecretVersion represents a previous version record of the secret.
+type SecretVersion struct {
+	ID         int       `db:"id"`
+	SecretID   int       `db:"secret_id"`
+	Version    int       `db:"version"`
+	CreatedAt  time.Time `db:"created_at"`
+	ReplacedAt time.Time `db:"replaced_at"`
+	ReplacedBy string    `db:"replaced_by"`
+	Name       string    `db:"name"`
+	AccessCode string    `db:"access_code"`
+	Value      string    `db:"value"`
+	DataKey    string    `db:"data_key"`
+}
+
+// QueryGetSecretVersionsBySecretID returns all previous versions of the given secret (without their encrypted fields)
+// from the database, starting from the latest one.
+func (d *Database) QueryGetSecretVersionsBySecretID(secretID int) (versions []*SecretVersion, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/version/getManyBySecretID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&versions, string(query), secretID); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// QueryGetSecretVersion returns the previous version of the given secret by its number from the database.
+func (d *Database) QueryGetSecretVersion(secretID, version int) (secretVersion SecretVersion, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/version/getOneBySecretIDAndVersion.sql")
+	if err != nil {
+		return secretVersion, err
+	}
+
+	// Get the record by its secret ID and number from the database.
+	if err := d.Connection.Get(&secretVersion, string(query), secretID, version); err != nil {
+		return secretVersion, err
+	}
+
+	return secretVersion, nil
+}
+
+// QueryGetSecretVersionsAfterID returns a batch of previous versions with the ID greater than the given one from the database.
+// The versions are returned as secrets with the ID of the version record and the protection fields of their secret,
+// so they are re-encrypted the same way as the secrets.
+func (d *Database) QueryGetSecretVersionsAfterID(id, limit int) (secrets []*Secret, err error) {
+	// Create a query from the embedded SQL file.
+	query, err := d.SQLQueries.ReadFile("sql_queries/version/getManyAfterID.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the records from the database.
+	if err := d.Connection.Select(&secrets, string(query), id, limit); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// QueryRotateVersionEncryptedFields updates the encrypted fields of the given previous versions in a single transaction.
+// The version is skipped, if its encrypted fields were changed since they were read. Returns the number of updated versions.
+func (d *Database) QueryRotateVersionEncryptedFields(rotations []*SecretRotation) (int64, error) {
+	return d.rotateEncryptedFields("sql_queries/version/rotateEncryptedFieldsOneByID.sql", rotations)
+}
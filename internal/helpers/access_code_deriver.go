@@ -0,0 +1,110 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// DeriveAccessCodeKey derives a new 32-byte key from the given access code with Argon2id and a random salt.
+// It returns the key and the encoded hash (in the PHC string format), which stores the Argon2id parameters,
+// the salt and a verifier of the access code, but cannot be used to get the key without the access code.
+func DeriveAccessCodeKey(accessCode string) (key []byte, encodedHash string, err error) {
+	// Generate a random salt.
+	salt := make([]byte, constants.ConstAccessCodeArgon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, "", err
+	}
+
+	// Derive the key and the verifier from the access code.
+	params := &argon2Params{
+		Memory:  constants.ConstAccessCodeArgon2Memory,
+		Time:    constants.ConstAccessCodeArgon2Time,
+		Threads: constants.ConstAccessCodeArgon2Threads,
+		Salt:    salt,
+	}
+	key, verifier := deriveAccessCodeKeyAndVerifier(accessCode, params)
+
+	// Encode the parameters, salt and verifier to the PHC string format.
+	params.Hash = verifier
+
+	return key, params.encode(), nil
+}
+
+// VerifyAccessCodeKey checks the given access code against the encoded hash in constant time,
+// and returns the key derived from the access code by the DeriveAccessCodeKey function.
+func VerifyAccessCodeKey(accessCode, encodedHash string) ([]byte, error) {
+	// Decode the parameters, salt and verifier from the PHC string format.
+	params, err := decodeArgon2Params(encodedHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the key and the verifier from the access code.
+	key, verifier := deriveAccessCodeKeyAndVerifier(accessCode, params)
+
+	// Compare the verifiers in constant time.
+	if subtle.ConstantTimeCompare(verifier, params.Hash) != 1 {
+		return nil, ErrAccessCodeNotValid
+	}
+
+	return key, nil
+}
+
+// deriveAccessCodeKeyAndVerifier derives 64 bytes from the access code with Argon2id,
+// the first half is used as the key and the second half as the verifier.
+func deriveAccessCodeKeyAndVerifier(accessCode string, params *argon2Params) (key, verifier []byte) {
+	derived := argon2.IDKey([]byte(accessCode), params.Salt, params.Time, params.Memory, params.Threads, 2*uint32(constants.ConstEncryptionKeySize))
+
+	return derived[:constants.ConstEncryptionKeySize], derived[constants.ConstEncryptionKeySize:]
+}
+
+// argon2Params represents the Argon2id parameters, salt and hash of the PHC string format.
+type argon2Params struct {
+	Memory, Time uint32
+	Threads      uint8
+	Salt, Hash   []byte
+}
+
+// encode returns the PHC string format of the Argon2id parameters, salt and hash.
+func (p *argon2Params) encode() string {
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(p.Salt), base64.RawStdEncoding.EncodeToString(p.Hash),
+	)
+}
+
+// decodeArgon2Params returns the Argon2id parameters, salt and hash from the PHC string format.
+func decodeArgon2Params(encodedHash string) (*argon2Params, error) {
+	// Split the PHC string into its parts.
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return nil, errors.New(messages.ErrAccessCodeHashNotValid)
+	}
+
+	// Parse the Argon2id parameters.
+	p := &argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return nil, errors.New(messages.ErrAccessCodeHashNotValid)
+	}
+
+	// Decode the salt and hash.
+	var err error
+	if p.Salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errors.New(messages.ErrAccessCodeHashNotValid)
+	}
+	if p.Hash, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, errors.New(messages.ErrAccessCodeHashNotValid)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// effLargeWordlist is the EFF's large wordlist for the diceware passphrases (7776 words).
+// See https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases for more details.
+//
+//go:embed wordlists/eff_large_wordlist.txt
+var effLargeWordlist string
+
+// passphraseWords returns the words of the embedded diceware wordlist.
+var passphraseWords = sync.OnceValue(func() []string {
+	// Split the wordlist into lines (each line is a dice roll and a word, separated by a tab).
+	lines := strings.Split(strings.TrimSpace(effLargeWordlist), "\n")
+
+	// Get the words from the lines.
+	words := make([]string, 0, len(lines))
+	for _, line := range lines {
+		_, word, _ := strings.Cut(line, "\t")
+		words = append(words, word)
+	}
+
+	return words
+})
+
+// accessCodeCharacterClasses is the mapping of the character class names to the characters.
+var accessCodeCharacterClasses = map[string]string{
+	"lower":   "abcdefghijklmnopqrstuvwxyz",
+	"upper":   "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"digits":  "0123456789",
+	"symbols": "!#$%&*+-=?@^_",
+}
+
+// AccessCodePolicy represents a policy for generating the access codes.
+type AccessCodePolicy struct {
+	Type             string
+	Length           int
+	CharacterClasses []string
+	ExcludeAmbiguous bool
+	Words            int
+	WordSeparator    string
+}
+
+// ParseAccessCodePolicy returns a new access code policy from the given string values (of the config or form)
+// after validation.
+func ParseAccessCodePolicy(policyType, length, characterClasses, excludeAmbiguous, words, wordSeparator string) (*AccessCodePolicy, error) {
+	policy := &AccessCodePolicy{
+		Type:             policyType,
+		CharacterClasses: SplitList(characterClasses),
+		WordSeparator:    wordSeparator,
+	}
+
+	// Parse the number values.
+	var err error
+	if policy.Length, err = strconv.Atoi(length); err != nil {
+		return nil, fmt.Errorf(
+			messages.ErrAccessCodePolicyLengthNotValid,
+			constants.ConstAccessCodePolicyMinLength, constants.ConstAccessCodePolicyMaxLength,
+		)
+	}
+	if policy.Words, err = strconv.Atoi(words); err != nil {
+		return nil, fmt.Errorf(
+			messages.ErrAccessCodePolicyWordsNotValid,
+			constants.ConstAccessCodePolicyMinWords, constants.ConstAccessCodePolicyMaxWords,
+		)
+	}
+
+	// Parse the boolean value (HTML checkboxes send 'on').
+	policy.ExcludeAmbiguous = slices.Contains([]string{"true", "on", "1"}, excludeAmbiguous)
+
+	// Validate the policy.
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Validate returns nil if the access code policy is valid.
+func (p *AccessCodePolicy) Validate() error {
+	switch p.Type {
+	case constants.ConstAccessCodePolicyTypeCharacters:
+		// Check the length of the access code.
+		if p.Length < constants.ConstAccessCodePolicyMinLength || p.Length > constants.ConstAccessCodePolicyMaxLength {
+			return fmt.Errorf(
+				messages.ErrAccessCodePolicyLengthNotValid,
+				constants.ConstAccessCodePolicyMinLength, constants.ConstAccessCodePolicyMaxLength,
+			)
+		}
+
+		// Check the character classes (at least one class and one character of each class should fit the length).
+		if len(p.CharacterClasses) == 0 || len(p.CharacterClasses) > p.Length {
+			return errors.New(messages.ErrAccessCodePolicyCharacterClassesNotValid)
+		}
+		for _, class := range p.CharacterClasses {
+			if _, ok := accessCodeCharacterClasses[class]; !ok {
+				return errors.New(messages.ErrAccessCodePolicyCharacterClassesNotValid)
+			}
+		}
+	case constants.ConstAccessCodePolicyTypePassphrase:
+		// Check the number of words of the passphrase.
+		if p.Words < constants.ConstAccessCodePolicyMinWords || p.Words > constants.ConstAccessCodePolicyMaxWords {
+			return fmt.Errorf(
+				messages.ErrAccessCodePolicyWordsNotValid,
+				constants.ConstAccessCodePolicyMinWords, constants.ConstAccessCodePolicyMaxWords,
+			)
+		}
+
+		// Check the separator of the words.
+		if len(p.WordSeparator) != 1 || !strings.Contains(constants.ConstAccessCodePolicyWordSeparators, p.WordSeparator) {
+			return errors.New(messages.ErrAccessCodePolicyWordSeparatorNotValid)
+		}
+	default:
+		return errors.New(messages.ErrAccessCodePolicyTypeNotValid)
+	}
+
+	return nil
+}
+
+// GenerateAccessCode generates a new random access code by the given policy.
+func GenerateAccessCode(policy *AccessCodePolicy) (string, error) {
+	// Check, if the policy is a passphrase.
+	if policy.Type == constants.ConstAccessCodePolicyTypePassphrase {
+		return generatePassphrase(policy.Words, policy.WordSeparator)
+	}
+
+	// Get the characters of each class.
+	classes := make([]string, 0, len(policy.CharacterClasses))
+	for _, class := range policy.CharacterClasses {
+		characters := accessCodeCharacterClasses[class]
+		if policy.ExcludeAmbiguous {
+			characters = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(constants.ConstAccessCodePolicyAmbiguousCharacters, r) {
+					return -1
+				}
+				return r
+			}, characters)
+		}
+		classes = append(classes, characters)
+	}
+
+	// Generate the access code until it contains at least one character of each class.
+	alphabet := strings.Join(classes, "")
+	for {
+		accessCode := make([]byte, policy.Length)
+		for i := range accessCode {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+			if err != nil {
+				return "", err
+			}
+			accessCode[i] = alphabet[n.Int64()]
+		}
+
+		if !slices.ContainsFunc(classes, func(characters string) bool {
+			return !strings.ContainsAny(string(accessCode), characters)
+		}) {
+			return string(accessCode), nil
+		}
+	}
+}
+
+// generatePassphrase generates a new random passphrase with the given number of words from the diceware wordlist.
+func generatePassphrase(words int, separator string) (string, error) {
+	wordlist := passphraseWords()
+
+	// Pick the random words from the wordlist.
+	passphrase := make([]string, 0, words)
+	for range words {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(wordlist))))
+		if err != nil {
+			return "", err
+		}
+		passphrase = append(passphrase, wordlist[n.Int64()])
+	}
+
+	return strings.Join(passphrase, separator), nil
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAccessCode(t *testing.T) {
+	// Test generating random characters with all classes
+	policy, err := ParseAccessCodePolicy("characters", "8", "lower,upper,digits,symbols", "true", "4", "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 100 {
+		accessCode, err := GenerateAccessCode(policy)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(accessCode) != 8 {
+			t.Errorf("unexpected access code length, got: %v, want: %v", len(accessCode), 8)
+		}
+
+		for _, characters := range []string{"abcdefghijkmnpqrstuvwxyz", "ABCDEFGHJKLMNPQRSTUVWXYZ", "23456789", "!#$%&*+-=?@^_"} {
+			if !strings.ContainsAny(accessCode, characters) {
+				t.Errorf("unexpected access code without characters %q, got: %v", characters, accessCode)
+			}
+		}
+
+		if strings.ContainsAny(accessCode, "0Oo1lI") {
+			t.Errorf("unexpected ambiguous characters, got: %v", accessCode)
+		}
+	}
+
+	// Test generating a passphrase
+	policy, err = ParseAccessCodePolicy("passphrase", "8", "lower", "false", "5", ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	accessCode, err := GenerateAccessCode(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if words := strings.Split(accessCode, "."); len(words) != 5 {
+		t.Errorf("unexpected number of words, got: %v, want: %v", len(words), 5)
+	}
+
+	// Test parsing the invalid policies
+	for _, values := range [][]string{
+		{"pin", "8", "digits", "true", "4", "-"},
+		{"characters", "5", "digits", "true", "4", "-"},
+		{"characters", "8", "emoji", "true", "4", "-"},
+		{"passphrase", "8", "lower", "true", "11", "-"},
+		{"passphrase", "8", "lower", "true", "4", "+"},
+	} {
+		if _, err := ParseAccessCodePolicy(values[0], values[1], values[2], values[3], values[4], values[5]); err == nil {
+			t.Errorf("unexpected nil error for the policy %v", values)
+		}
+	}
+}
+
+func TestEstimateAccessCodeStrength(t *testing.T) {
+	// Test the weak access codes
+	for _, accessCode := range []string{"password1", "123456789012", "aaaaaaaaaaaa", "abcdefghijkl"} {
+		if strength := EstimateAccessCodeStrength(accessCode); strength >= 40 {
+			t.Errorf("unexpected strength for %q, got: %v, want: less than %v", accessCode, strength, 40)
+		}
+	}
+
+	// Test the strong access codes
+	for _, accessCode := range []string{"correct-horse-battery-staple", "8aswy6i2rh"} {
+		if strength := EstimateAccessCodeStrength(accessCode); strength < 40 {
+			t.Errorf("unexpected strength for %q, got: %v, want: at least %v", accessCode, strength, 40)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// ErrAccessCodeNotValid is returned when the access code does not match the access code hash (or key verifier).
+var ErrAccessCodeNotValid = errors.New(messages.ErrSecretAccessCodeNotValid)
+
+// HashAccessCode returns the Argon2id hash of the given access code with a random salt (in the PHC string format).
+func HashAccessCode(accessCode string) (string, error) {
+	// Generate a random salt.
+	salt := make([]byte, constants.ConstAccessCodeArgon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	// Hash the access code.
+	params := &argon2Params{
+		Memory:  constants.ConstAccessCodeArgon2Memory,
+		Time:    constants.ConstAccessCodeArgon2Time,
+		Threads: constants.ConstAccessCodeArgon2Threads,
+		Salt:    salt,
+	}
+	params.Hash = argon2.IDKey([]byte(accessCode), params.Salt, params.Time, params.Memory, params.Threads, uint32(constants.ConstEncryptionKeySize))
+
+	return params.encode(), nil
+}
+
+// VerifyAccessCodeHash checks the given access code against the encoded hash in constant time.
+func VerifyAccessCodeHash(accessCode, encodedHash string) error {
+	// Decode the parameters, salt and hash from the PHC string format.
+	params, err := decodeArgon2Params(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	// Hash the access code with the same parameters and salt.
+	hash := argon2.IDKey([]byte(accessCode), params.Salt, params.Time, params.Memory, params.Threads, uint32(len(params.Hash)))
+
+	// Compare the hashes in constant time.
+	if subtle.ConstantTimeCompare(hash, params.Hash) != 1 {
+		return ErrAccessCodeNotValid
+	}
+
+	return nil
+}
+
+// IsAccessCodeHash returns true if the given access code value is an Argon2id hash (in the PHC string format).
+func IsAccessCodeHash(value string) bool {
+	return strings.HasPrefix(value, "$argon2id$")
+}
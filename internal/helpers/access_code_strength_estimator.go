@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// passphraseWordSet returns the words of the embedded diceware wordlist as a set.
+var passphraseWordSet = sync.OnceValue(func() map[string]bool {
+	words := passphraseWords()
+
+	// Create a set of the words.
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+
+	return set
+})
+
+// EstimateAccessCodeStrength returns the estimated strength (entropy in bits) of the given access code.
+//
+// The estimation is pessimistic: the words of the diceware wordlist are counted as one choice from the
+// wordlist, the repeated and sequential characters are counted as one bit, and every other character
+// is counted as one choice from all characters of its classes used in the access code.
+func EstimateAccessCodeStrength(accessCode string) float64 {
+	runes := []rune(accessCode)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	// Calculate the size of the alphabet of the access code.
+	var hasLower, hasUpper, hasDigits, hasOther bool
+	for _, r := range runes {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigits = true
+		default:
+			hasOther = true
+		}
+	}
+	alphabetSize := 0
+	for _, class := range []struct {
+		ok   bool
+		size int
+	}{{hasLower, 26}, {hasUpper, 26}, {hasDigits, 10}, {hasOther, 33}} {
+		if class.ok {
+			alphabetSize += class.size
+		}
+	}
+
+	// Get the bits of the each choice.
+	characterBits := math.Log2(float64(alphabetSize))
+	wordBits := math.Log2(float64(len(passphraseWords())))
+
+	// Sum the bits of the words and characters of the access code.
+	bits := 0.0
+	lowered := []rune(strings.ToLower(accessCode))
+	for i := 0; i < len(runes); {
+		// Check, if the longest diceware word (at least 3 characters) starts at the current position.
+		if length := longestPassphraseWordAt(lowered, i); length > 0 {
+			bits += min(wordBits, float64(length)*characterBits)
+			i += length
+			continue
+		}
+
+		// Check, if the character repeats or continues the sequence of the previous one.
+		if i > 0 && (lowered[i] == lowered[i-1] || lowered[i]-lowered[i-1] == 1 || lowered[i-1]-lowered[i] == 1) {
+			bits++
+		} else {
+			bits += characterBits
+		}
+		i++
+	}
+
+	return bits
+}
+
+// longestPassphraseWordAt returns the length of the longest diceware word at the given position, or 0.
+func longestPassphraseWordAt(runes []rune, position int) int {
+	set := passphraseWordSet()
+
+	// Check the longest words first (the longest diceware word is 9 characters).
+	for length := min(9, len(runes)-position); length >= 3; length-- {
+		if set[string(runes[position:position+length])] {
+			return length
+		}
+	}
+
+	return 0
+}
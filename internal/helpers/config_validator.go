@@ -13,24 +13,58 @@ import (
 
 // ConfigValidation validates the configuration settings.
 //
-// This function checks the validity of various configuration settings such as the secret key,
+// This function checks the validity of various configuration settings such as the key provider, the secret keys,
 // master username, master password, domain URL, domain HTTP schema, and server timezone.
 // It returns an error if any of the configuration settings are invalid.
 //
 // Returns:
 // - error: An error indicating the invalid configuration setting, or nil if all settings are valid.
 func ConfigValidation() error {
-	// Check SECRET_KEY.
-	secretKey := os.Getenv("SECRET_KEY")
-	if secretKey == "" {
-		return errors.New(messages.ErrConfigSecretKeyEmpty)
+	// Check the files of the configuration variables with the "_FILE" suffix.
+	if err := ValidateConfigFiles(); err != nil {
+		return err
 	}
-	if len(secretKey) < constants.ConstConfigSecretKeyMinLength {
-		return fmt.Errorf(messages.ErrConfigSecretKeyLengthNotValid, constants.ConstConfigSecretKeyMinLength)
+
+	// Check KEY_PROVIDER and its settings.
+	switch keyProvider := Getenv("KEY_PROVIDER", constants.ConstConfigKeyProvider); keyProvider {
+	case constants.ConstKeyProviderEnv, constants.ConstKeyProviderFile:
+		// Check SECRET_KEY_FILE (the file key provider reads the secret key only from the file).
+		if keyProvider == constants.ConstKeyProviderFile && os.Getenv("SECRET_KEY_FILE") == "" {
+			return errors.New(messages.ErrConfigSecretKeyFileEmpty)
+		}
+
+		// Check SECRET_KEY.
+		secretKey := Getenv("SECRET_KEY", "")
+		if secretKey == "" {
+			return errors.New(messages.ErrConfigSecretKeyEmpty)
+		}
+		if len(secretKey) < constants.ConstConfigSecretKeyMinLength {
+			return fmt.Errorf(messages.ErrConfigSecretKeyLengthNotValid, constants.ConstConfigSecretKeyMinLength)
+		}
+	case constants.ConstKeyProviderVault:
+		// Check VAULT_ADDR, VAULT_TOKEN and VAULT_TRANSIT_KEY.
+		if vaultAddress := Getenv("VAULT_ADDR", ""); vaultAddress == "" || IsValidURL(vaultAddress) != nil {
+			return errors.New(messages.ErrConfigVaultAddressNotValid)
+		}
+		if Getenv("VAULT_TOKEN", "") == "" {
+			return errors.New(messages.ErrConfigVaultTokenEmpty)
+		}
+		if Getenv("VAULT_TRANSIT_KEY", "") == "" {
+			return errors.New(messages.ErrConfigVaultTransitKeyEmpty)
+		}
+	default:
+		return errors.New(messages.ErrConfigKeyProviderNotValid)
+	}
+
+	// Check SECRET_KEYS_PREVIOUS.
+	for i, previousSecretKey := range SplitList(Getenv("SECRET_KEYS_PREVIOUS", "")) {
+		if len(previousSecretKey) < constants.ConstConfigSecretKeyMinLength {
+			return fmt.Errorf(messages.ErrConfigPreviousSecretKeyLengthNotValid, i+1, constants.ConstConfigSecretKeyMinLength)
+		}
 	}
 
 	// Check MASTER_USERNAME.
-	masterUsername := os.Getenv("MASTER_USERNAME")
+	masterUsername := Getenv("MASTER_USERNAME", "")
 	if masterUsername == "" {
 		return errors.New(messages.ErrConfigMasterUsernameEmpty)
 	}
@@ -44,7 +78,7 @@ func ConfigValidation() error {
 	}
 
 	// Check MASTER_PASSWORD.
-	masterPassword := os.Getenv("MASTER_PASSWORD")
+	masterPassword := Getenv("MASTER_PASSWORD", "")
 	if masterPassword == "" {
 		return errors.New(messages.ErrConfigMasterPasswordEmpty)
 	}
@@ -58,7 +92,7 @@ func ConfigValidation() error {
 	}
 
 	// Check DOMAIN.
-	domain := os.Getenv("DOMAIN")
+	domain := Getenv("DOMAIN", "")
 	if domain != "" {
 		if err := IsValidURL(domain); err != nil {
 			return err
@@ -66,7 +100,7 @@ func ConfigValidation() error {
 	}
 
 	// Check DOMAIN_SCHEMA.
-	domainSchema := os.Getenv("DOMAIN_SCHEMA")
+	domainSchema := Getenv("DOMAIN_SCHEMA", "")
 	if domainSchema != "" {
 		if !slices.Contains([]string{"https", "http"}, domainSchema) {
 			return errors.New(messages.ErrConfigDomainSchemaNotValid)
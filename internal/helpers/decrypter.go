@@ -1,26 +1,112 @@
 package helpers
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
 )
 
-// DecryptString decrypts a string that was encrypted using AES encryption with a given secret key.
-func DecryptString(secretKey, encryptedText string) (string, error) {
-	// Create a new AES cipher block using the key.
+// DecryptString decrypts a string that was encrypted by the EncryptString or EncryptStringWithKeyID functions
+// with a given 32-byte key.
+func DecryptString(key []byte, encryptedText string) (string, error) {
+	// Split the version prefix from the encrypted text.
+	version, payload, _ := strings.Cut(encryptedText, ":")
+
+	// Get the key ID as additional data, if the version has it.
+	var additionalData []byte
+	switch version {
+	case constants.ConstEncryptionCiphertextVersion:
+	case constants.ConstEncryptionKeyIDCiphertextVersion:
+		keyID, rest, found := strings.Cut(payload, ":")
+		if !found {
+			return "", errors.New(messages.ErrEncryptedTextNotValid)
+		}
+		additionalData, payload = []byte(keyID), rest
+	default:
+		return "", errors.New(messages.ErrEncryptedTextVersionNotSupported)
+	}
+
+	// Create a new AES-GCM cipher using the key.
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	return openGCM(aead, payload, additionalData)
+}
+
+// DecryptLegacyString decrypts a string that was encrypted in the legacy format (AES in CBC mode without
+// a version prefix) with a given secret key, which is padded to 16 characters as the AES key.
+func DecryptLegacyString(secretKey, encryptedText string) (string, error) {
+	// Create a new AES cipher block using the padded key.
 	block, err := aes.NewCipher([]byte(fmt.Sprintf("%-16s", secretKey)))
 	if err != nil {
 		return "", err
 	}
 
+	return decryptCBCString(block, encryptedText)
+}
+
+// ParseEncryptedStringKeyID returns the key ID of the given encrypted text, if the envelope version has it
+// (the envelopes encrypted with the local key or by the Vault transit engine).
+func ParseEncryptedStringKeyID(encryptedText string) (string, bool) {
+	// Split the version prefix from the encrypted text.
+	version, payload, _ := strings.Cut(encryptedText, ":")
+	if version != constants.ConstEncryptionKeyIDCiphertextVersion && version != constants.ConstEncryptionVaultCiphertextVersion {
+		return "", false
+	}
+
+	// Split the key ID from the payload.
+	keyID, _, found := strings.Cut(payload, ":")
+
+	return keyID, found
+}
+
+// IsEncryptedStringLegacy returns true if the given encrypted text is in the legacy format without a version prefix,
+// which must be decrypted by the DecryptLegacyString function.
+func IsEncryptedStringLegacy(encryptedText string) bool {
+	return !strings.Contains(encryptedText, ":")
+}
+
+// openGCM decodes the base64 payload and opens it with the given AES-GCM cipher.
+func openGCM(aead cipher.AEAD, payload string, additionalData []byte) (string, error) {
+	// Decode the encrypted text from base64 to bytes.
+	ciphertext, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(ciphertext) < aead.NonceSize() {
+		return "", errors.New(messages.ErrEncryptedTextNotValid)
+	}
+
+	// Open the ciphertext with the nonce from the beginning of it.
+	decryptedText, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return "", errors.New(messages.ErrEncryptedTextNotValid)
+	}
+
+	// Return the decrypted text as a string.
+	return string(decryptedText), nil
+}
+
+// decryptCBCString decrypts a hexadecimal string that was encrypted using AES in CBC mode with a given cipher block.
+func decryptCBCString(block cipher.Block, encryptedText string) (string, error) {
 	// Decode the encrypted text from hexadecimal to bytes.
 	ciphertext, err := hex.DecodeString(encryptedText)
 	if err != nil {
 		return "", err
 	}
 
+	// Check, if the ciphertext has the IV and at least one full block.
+	if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+		return "", errors.New(messages.ErrEncryptedTextNotValid)
+	}
+
 	// Get the initialization vector (IV) from the beginning of the ciphertext.
 	iv := ciphertext[:aes.BlockSize]
 	// Remove the IV from the ciphertext
@@ -34,8 +120,13 @@ func DecryptString(secretKey, encryptedText string) (string, error) {
 	// Decrypt the ciphertext and store the result in the decryptedText buffer.
 	mode.CryptBlocks(decryptedText, ciphertext)
 
-	// Get the padding value from the last byte of the decrypted text.
+	// Get the padding value from the last byte of the decrypted text and check it.
 	padding := int(decryptedText[len(decryptedText)-1])
+	if padding == 0 || padding > aes.BlockSize ||
+		!bytes.Equal(decryptedText[len(decryptedText)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return "", errors.New(messages.ErrEncryptedTextNotValid)
+	}
+
 	// Remove the padding from the decrypted text
 	decryptedText = decryptedText[:len(decryptedText)-padding]
 
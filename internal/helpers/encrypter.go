@@ -1,46 +1,69 @@
 package helpers
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"encoding/hex"
-	"fmt"
+	"encoding/base64"
+
+	"github.com/secretium/secretium/internal/constants"
 )
 
-// EncryptString encrypts a string using AES encryption with a given secret key.
-// It generates a random initialization vector (IV), encrypts the text using AES in CBC mode,
-// appends the IV to the ciphertext, and returns the ciphertext as a hexadecimal string.
-func EncryptString(secretKey, text string) (string, error) {
-	// Create a new AES cipher using the key.
-	block, err := aes.NewCipher([]byte(fmt.Sprintf("%-16s", secretKey)))
-	if block == nil || err != nil {
+// EncryptString encrypts a string using AES-GCM authenticated encryption with a given 32-byte key.
+// It generates a random nonce, seals the text with AES in GCM mode, prepends the nonce to the ciphertext,
+// and returns a versioned envelope in the "v3:<base64 of nonce and ciphertext>" format.
+func EncryptString(key []byte, text string) (string, error) {
+	// Seal the text with the key.
+	payload, err := sealGCM(key, text, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Return the ciphertext as a versioned envelope.
+	return constants.ConstEncryptionCiphertextVersion + ":" + payload, nil
+}
+
+// EncryptStringWithKeyID encrypts a string like the EncryptString function, but tags the envelope with the given key ID
+// in the "v4:<key ID>:<base64 of nonce and ciphertext>" format. The key ID is authenticated as additional data.
+func EncryptStringWithKeyID(keyID string, key []byte, text string) (string, error) {
+	// Seal the text with the key and the key ID.
+	payload, err := sealGCM(key, text, []byte(keyID))
+	if err != nil {
 		return "", err
 	}
 
-	// Generate a random initialization vector (IV).
-	iv := make([]byte, aes.BlockSize)
-	if _, err := rand.Read(iv); err != nil {
+	// Return the ciphertext as a versioned envelope with the key ID.
+	return constants.ConstEncryptionKeyIDCiphertextVersion + ":" + keyID + ":" + payload, nil
+}
+
+// sealGCM seals the text with AES-GCM and returns the base64 string of the nonce and ciphertext.
+func sealGCM(key []byte, text string, additionalData []byte) (string, error) {
+	// Create a new AES-GCM cipher using the key.
+	aead, err := newGCM(key)
+	if err != nil {
 		return "", err
 	}
 
-	// Pad the text with bytes representing the number of padding bytes.
-	paddedText := []byte(text)
-	blockSize := block.BlockSize()
-	padding := blockSize - len(paddedText)%blockSize
-	paddedText = append(paddedText, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	// Generate a random nonce.
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
 
-	// Create a buffer to hold the ciphertext with room for the IV.
-	ciphertext := make([]byte, aes.BlockSize+len(paddedText))
+	// Seal the text and append the ciphertext to the nonce.
+	ciphertext := aead.Seal(nonce, nonce, []byte(text), additionalData)
 
-	// Encrypt the padded text using AES in CBC mode.
-	mode := cipher.NewCBCEncrypter(block, iv)
-	mode.CryptBlocks(ciphertext[aes.BlockSize:], paddedText)
+	return base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
 
-	// Copy the IV to the beginning of the ciphertext.
-	copy(ciphertext[:aes.BlockSize], iv)
+// newGCM returns a new AES cipher in GCM mode with the given key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	// Create a new AES cipher block using the key.
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
 
-	// Return the ciphertext as a hexadecimal string.
-	return hex.EncodeToString(ciphertext), nil
+	// Create a new GCM mode with the block.
+	return cipher.NewGCM(block)
 }
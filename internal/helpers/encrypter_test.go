@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecryptString(t *testing.T) {
+	key, _ := DeriveKey("this-is-my-secret-key-123", "test")
+	wrongKey, _ := DeriveKey("this-is-my-secret-key-123", "wrong")
+
+	// Test encrypting and decrypting a string
+	encrypted, err := EncryptString(key, "my secret value")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(encrypted, "v3:") {
+		t.Errorf("unexpected envelope version, got: %v, want prefix: %v", encrypted, "v3:")
+	}
+
+	decrypted, err := DecryptString(key, encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decrypted != "my secret value" {
+		t.Errorf("unexpected decrypted text, got: %v, want: %v", decrypted, "my secret value")
+	}
+
+	// Test decrypting a tampered string
+	tampered := []byte(encrypted)
+	if tampered[10] == 'A' {
+		tampered[10] = 'B'
+	} else {
+		tampered[10] = 'A'
+	}
+	if _, err := DecryptString(key, string(tampered)); err == nil {
+		t.Errorf("expected error for tampered ciphertext, got: nil")
+	}
+
+	// Test decrypting with a wrong key
+	if _, err := DecryptString(wrongKey, encrypted); err == nil {
+		t.Errorf("expected error for wrong key, got: nil")
+	}
+}
+
+func TestEncryptStringWithKeyID(t *testing.T) {
+	key, _ := DeriveKey("this-is-my-secret-key-123", "test")
+
+	// Test encrypting a string with a key ID
+	encrypted, err := EncryptStringWithKeyID("a1b2c3d4", key, "my secret value")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keyID, found := ParseEncryptedStringKeyID(encrypted)
+	if !found || keyID != "a1b2c3d4" {
+		t.Errorf("unexpected key ID, got: %v, want: %v", keyID, "a1b2c3d4")
+	}
+
+	decrypted, err := DecryptString(key, encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decrypted != "my secret value" {
+		t.Errorf("unexpected decrypted text, got: %v, want: %v", decrypted, "my secret value")
+	}
+
+	// Test decrypting a string with a replaced key ID
+	if _, err := DecryptString(key, strings.Replace(encrypted, "a1b2c3d4", "d4c3b2a1", 1)); err == nil {
+		t.Errorf("expected error for replaced key ID, got: nil")
+	}
+}
+
+func TestDecryptLegacyString(t *testing.T) {
+	secretKey := "0123456789abcdef"
+
+	// Encrypt a string in the legacy format (AES in CBC mode)
+	block, _ := aes.NewCipher([]byte(fmt.Sprintf("%-16s", secretKey)))
+	paddedText := []byte("legacy value")
+	padding := aes.BlockSize - len(paddedText)%aes.BlockSize
+	paddedText = append(paddedText, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	ciphertext := make([]byte, aes.BlockSize+len(paddedText))
+	cipher.NewCBCEncrypter(block, ciphertext[:aes.BlockSize]).CryptBlocks(ciphertext[aes.BlockSize:], paddedText)
+	legacy := hex.EncodeToString(ciphertext)
+
+	// Test decrypting a legacy string
+	decrypted, err := DecryptLegacyString(secretKey, legacy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decrypted != "legacy value" {
+		t.Errorf("unexpected decrypted text, got: %v, want: %v", decrypted, "legacy value")
+	}
+
+	if !IsEncryptedStringLegacy(legacy) {
+		t.Errorf("unexpected legacy flag, got: %v, want: %v", false, true)
+	}
+
+	// Test decrypting malformed legacy strings without panic
+	for _, malformed := range []string{"", "00", hex.EncodeToString(make([]byte, aes.BlockSize)), legacy[:len(legacy)-2]} {
+		if _, err := DecryptLegacyString(secretKey, malformed); err == nil {
+			t.Errorf("expected error for malformed ciphertext %q, got: nil", malformed)
+		}
+	}
+}
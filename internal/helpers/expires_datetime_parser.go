@@ -0,0 +1,123 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// expiresDatetimeLayouts is the list of the layouts of the exact expiration datetime (the first one is
+// sent by the browser with the time zone, the others are the values of the datetime-local inputs).
+var expiresDatetimeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02T15:04:05"}
+
+// ParseExpiresDatetime returns a new datetime for the given value, which is either a duration since now
+// (any Go duration, like 90m or 36h, or a number of days, like 7d) or an exact datetime (the datetimes
+// without a time zone are in the server time zone). The time between now and the new datetime
+// should be between the given minimum and maximum TTL.
+func ParseExpiresDatetime(now time.Time, value string, minTTL, maxTTL time.Duration) (time.Time, error) {
+	expiresAt, err := parseExpiresDatetime(now, strings.TrimSpace(value))
+	if err != nil {
+		return now, err
+	}
+
+	// Check, if the time to live is between the minimum and maximum.
+	if ttl := expiresAt.Sub(now); ttl < minTTL || ttl > maxTTL {
+		return now, fmt.Errorf(messages.ErrSecretExpiresAtOutOfRange, FormatDuration(minTTL), FormatDuration(maxTTL))
+	}
+
+	return expiresAt, nil
+}
+
+// ParseAvailableDatetime returns a new datetime, when the secret becomes available, for the given value, which is
+// either a duration since now or an exact datetime (like in the ParseExpiresDatetime function). The empty value
+// and the datetimes in the past mean, that the secret is available right away. The time between now and the new
+// datetime should not be greater than the given maximum delay.
+func ParseAvailableDatetime(now time.Time, value string, maxDelay time.Duration) (time.Time, error) {
+	// Check, if the value is empty.
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now, nil
+	}
+
+	availableAt, err := parseExpiresDatetime(now, value)
+	if err != nil {
+		return now, errors.New(messages.ErrSecretAvailableAtNotValid)
+	}
+
+	// Check, if the datetime is in the past.
+	if availableAt.Before(now) {
+		return now, nil
+	}
+
+	// Check, if the datetime is not too late.
+	if availableAt.Sub(now) > maxDelay {
+		return now, fmt.Errorf(messages.ErrSecretAvailableAtOutOfRange, FormatDuration(maxDelay))
+	}
+
+	return availableAt, nil
+}
+
+// CheckSecretLifetime returns an error, if the secret created at the given datetime would live longer than the given
+// maximum lifetime until the given expiration datetime (zero maximum lifetime means, that it is unlimited).
+// The lifetime is always counted since the creation, so a scheduled secret has less time after it becomes available.
+func CheckSecretLifetime(createdAt, expiresAt time.Time, maxLifetime time.Duration) error {
+	// Check, if the total lifetime of the secret is not exceeded.
+	if maxLifetime > 0 && expiresAt.Sub(createdAt) > maxLifetime {
+		return fmt.Errorf(
+			messages.ErrSecretLifetimeExceeded,
+			FormatDuration(maxLifetime),
+			createdAt.Add(maxLifetime).Format("Mon, 02 Jan 2006 15:04"),
+		)
+	}
+
+	return nil
+}
+
+// FormatDuration returns a human-readable duration in the largest whole units (days, hours or minutes).
+func FormatDuration(d time.Duration) string {
+	// Get the largest whole unit of the duration.
+	count, unit := int64(d/time.Minute), "minute"
+	switch {
+	case d%time.Minute != 0:
+		return d.String()
+	case d != 0 && d%(time.Hour*24) == 0:
+		count, unit = int64(d/(time.Hour*24)), "day"
+	case d != 0 && d%time.Hour == 0:
+		count, unit = int64(d/time.Hour), "hour"
+	}
+
+	// Add the plural suffix.
+	if count != 1 {
+		unit += "s"
+	}
+
+	return fmt.Sprintf("%d %s", count, unit)
+}
+
+// parseExpiresDatetime returns a new datetime for the given duration since now or exact datetime.
+func parseExpiresDatetime(now time.Time, value string) (time.Time, error) {
+	// Check, if the value is a number of days (not supported by the Go durations).
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if count, err := strconv.Atoi(days); err == nil {
+			return now.Add(time.Hour * 24 * time.Duration(count)), nil
+		}
+	}
+
+	// Check, if the value is a Go duration.
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(duration), nil
+	}
+
+	// Check, if the value is an exact datetime.
+	for _, layout := range expiresDatetimeLayouts {
+		if datetime, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return datetime.In(now.Location()), nil
+		}
+	}
+
+	return now, errors.New(messages.ErrSecretExpiresAtNotValid)
+}
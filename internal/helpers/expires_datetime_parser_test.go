@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExpiresDatetime(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, location)
+	minTTL, maxTTL := time.Minute*5, time.Hour*24*30
+
+	// Test the valid durations and datetimes
+	for value, want := range map[string]time.Time{
+		"5m":                        now.Add(time.Minute * 5),
+		"1h":                        now.Add(time.Hour),
+		"36h":                       now.Add(time.Hour * 36),
+		"1h30m":                     now.Add(time.Minute * 90),
+		"7d":                        now.Add(time.Hour * 24 * 7),
+		"30d":                       now.Add(time.Hour * 24 * 30),
+		"2024-01-03T10:30":          time.Date(2024, 1, 3, 10, 30, 0, 0, location),
+		"2024-01-03T10:30:15":       time.Date(2024, 1, 3, 10, 30, 15, 0, location),
+		"2024-01-03T07:30:00Z":      time.Date(2024, 1, 3, 10, 30, 0, 0, location),
+		"2024-01-03T10:30:00+03:00": time.Date(2024, 1, 3, 10, 30, 0, 0, location),
+	} {
+		got, err := ParseExpiresDatetime(now, value, minTTL, maxTTL)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("unexpected datetime for %q, got: %v, want: %v", value, got, want)
+		}
+	}
+
+	// Test the invalid values and the values out of the TTL range
+	for _, value := range []string{"", "soon", "1w", "-1h", "1m", "31d", "2023-12-31T10:30", "2024-03-01T10:30"} {
+		if _, err := ParseExpiresDatetime(now, value, minTTL, maxTTL); err == nil {
+			t.Errorf("unexpected nil error for %q", value)
+		}
+	}
+}
+
+func TestParseAvailableDatetime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test the valid durations and datetimes (the empty value and the past datetimes mean now)
+	for value, want := range map[string]time.Time{
+		"":                 now,
+		"3d":               now.Add(time.Hour * 24 * 3),
+		"2024-01-08T09:00": time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+		"2023-12-31T09:00": now,
+	} {
+		got, err := ParseAvailableDatetime(now, value, time.Hour*24*7)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("unexpected datetime for %q, got: %v, want: %v", value, got, want)
+		}
+	}
+
+	// Test the invalid value and the datetime after the maximum delay
+	for _, value := range []string{"next monday", "8d", "2024-01-08T12:01"} {
+		if _, err := ParseAvailableDatetime(now, value, time.Hour*24*7); err == nil {
+			t.Errorf("unexpected nil error for %q", value)
+		}
+	}
+}
+
+func TestCheckSecretLifetime(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test the lifetime within the maximum and without the maximum
+	if err := CheckSecretLifetime(createdAt, createdAt.Add(time.Hour*24*7), time.Hour*24*7); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckSecretLifetime(createdAt, createdAt.Add(time.Hour*24*365), 0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Test the lifetime of the scheduled secret, which is counted since its creation
+	if err := CheckSecretLifetime(createdAt, createdAt.Add(time.Hour*24*7+time.Minute), time.Hour*24*7); err == nil {
+		t.Errorf("unexpected nil error for the exceeded lifetime")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		time.Minute:      "1 minute",
+		time.Minute * 5:  "5 minutes",
+		time.Hour * 36:   "36 hours",
+		time.Hour * 24:   "1 day",
+		time.Hour * 720:  "30 days",
+		time.Second * 90: "1m30s",
+		time.Duration(0): "0 minutes",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("unexpected duration, got: %v, want: %v", got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	"github.com/secretium/secretium/internal/constants"
@@ -13,7 +14,8 @@ type ErrorField struct {
 }
 
 // ValidateAddSecretForm returns nil if the given add secret form values are valid.
-func ValidateAddSecretForm(name, value string) (errorFields []*messages.ErrorField) {
+// The access code is optional, the empty access code is generated by the access code policy.
+func ValidateAddSecretForm(name, value, accessCode string, isClientEncrypted bool) (errorFields []*messages.ErrorField) {
 	// Check if the name is empty or not valid (length should be greater than 3 and less than 32).
 	if name == "" ||
 		len(name) < constants.ConstFormAddSecretNameMinLength ||
@@ -47,6 +49,210 @@ func ValidateAddSecretForm(name, value string) (errorFields []*messages.ErrorFie
 		)
 	}
 
+	// Check if the value is encrypted in the browser (base64url of the 12-byte IV and at least the 16-byte tag).
+	if isClientEncrypted {
+		ciphertext, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(ciphertext) < constants.ConstFormAddSecretClientEncryptedValueMinLength {
+			// Append error field.
+			errorFields = append(
+				errorFields,
+				&messages.ErrorField{
+					Name:    "Value",
+					Message: messages.ErrFormAddSecretClientEncryptedValueNotValid,
+				},
+			)
+		}
+	}
+
+	// Check if the custom access code is not valid (length should be greater than 6 and less than 128).
+	if accessCode != "" {
+		if len(accessCode) < constants.ConstFormAddSecretAccessCodeMinLength ||
+			len(accessCode) > constants.ConstFormAddSecretAccessCodeMaxLength {
+			// Append error field.
+			errorFields = append(
+				errorFields,
+				&messages.ErrorField{
+					Name: "Access code",
+					Message: fmt.Sprintf(
+						messages.ErrFormAddSecretAccessCodeLengthNotValid,
+						constants.ConstFormAddSecretAccessCodeMinLength,
+						constants.ConstFormAddSecretAccessCodeMaxLength,
+					),
+				},
+			)
+		} else if strength := EstimateAccessCodeStrength(accessCode); strength < constants.ConstFormAddSecretAccessCodeMinStrength {
+			// Append error field.
+			errorFields = append(
+				errorFields,
+				&messages.ErrorField{
+					Name: "Access code",
+					Message: fmt.Sprintf(
+						messages.ErrFormAddSecretAccessCodeNotStrong,
+						strength,
+						constants.ConstFormAddSecretAccessCodeMinStrength,
+					),
+				},
+			)
+		}
+	}
+
+	return errorFields
+}
+
+// ValidateEditSecretForm returns nil if the given edit secret form values are valid.
+// The value is optional, the empty value means, that only the name of the secret is edited.
+func ValidateEditSecretForm(name, value string, isValueEditable bool) (errorFields []*messages.ErrorField) {
+	// Check if the name is empty or not valid (length should be greater than 3 and less than 32).
+	if name == "" ||
+		len(name) < constants.ConstFormAddSecretNameMinLength ||
+		len(name) > constants.ConstFormAddSecretNameMaxLength {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name: "Name",
+				Message: fmt.Sprintf(
+					messages.ErrFormAddSecretNameLengthNotValid,
+					constants.ConstFormAddSecretNameMinLength,
+					constants.ConstFormAddSecretNameMaxLength,
+				),
+			},
+		)
+	}
+
+	// Check if the value is set, but it cannot be re-encrypted by the server.
+	if value != "" && !isValueEditable {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Value",
+				Message: messages.ErrFormEditSecretValueNotEditable,
+			},
+		)
+	}
+
+	return errorFields
+}
+
+// ValidateMaxViewsSecretForm returns nil if the given maximum number of views of the add secret form is valid.
+// The zero number of views means, that the secret has unlimited views.
+func ValidateMaxViewsSecretForm(maxViews int) (errorFields []*messages.ErrorField) {
+	// Check if the number of views is not valid (should be between 0 and 100).
+	if maxViews < 0 || maxViews > constants.ConstFormAddSecretMaxViewsMax {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Views",
+				Message: fmt.Sprintf(messages.ErrFormAddSecretMaxViewsNotValid, constants.ConstFormAddSecretMaxViewsMax),
+			},
+		)
+	}
+
+	return errorFields
+}
+
+// ValidateSplitSecretForm returns nil if the given split secret form values are valid.
+// The zero number of shares means, that the secret is not split.
+func ValidateSplitSecretForm(sharesTotal, sharesThreshold int, accessCode string, isClientEncrypted, isAccessCodeProtected bool) (errorFields []*messages.ErrorField) {
+	// Check if the secret is not split.
+	if sharesTotal == 0 && sharesThreshold == 0 {
+		return nil
+	}
+
+	// Check if the number of shares and the threshold are not valid (2 <= threshold <= shares <= 10).
+	if sharesThreshold < constants.ConstSecretSharesMinThreshold ||
+		sharesThreshold > sharesTotal ||
+		sharesTotal > constants.ConstSecretSharesMaxTotal {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name: "Shares",
+				Message: fmt.Sprintf(
+					messages.ErrFormAddSecretSharesNotValid,
+					constants.ConstSecretSharesMinThreshold,
+					constants.ConstSecretSharesMaxTotal,
+					constants.ConstSecretSharesMinThreshold,
+				),
+			},
+		)
+	}
+
+	// Check if the split secret is combined with the incompatible options.
+	if accessCode != "" || isClientEncrypted || isAccessCodeProtected {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Shares",
+				Message: messages.ErrFormAddSecretSharesNotCompatible,
+			},
+		)
+	}
+
+	return errorFields
+}
+
+// ValidateRecipientSecretForm returns nil if the given recipient public key of the add secret form is valid.
+// The empty public key means, that the secret is not encrypted to the recipient.
+func ValidateRecipientSecretForm(publicKey string, isClientEncrypted bool) (errorFields []*messages.ErrorField) {
+	// Check if the secret is not encrypted to the recipient.
+	if publicKey == "" {
+		return nil
+	}
+
+	// Check if the public key is too long.
+	if len(publicKey) > constants.ConstFormAddSecretRecipientPublicKeyMaxLength {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name: "Recipient public key",
+				Message: fmt.Sprintf(
+					messages.ErrFormAddSecretRecipientPublicKeyLengthNotValid,
+					constants.ConstFormAddSecretRecipientPublicKeyMaxLength,
+				),
+			},
+		)
+	}
+
+	// Check if the value is already encrypted in the browser (the server cannot encrypt it to the recipient).
+	if isClientEncrypted {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Recipient public key",
+				Message: messages.ErrFormAddSecretRecipientNotCompatible,
+			},
+		)
+	}
+
+	return errorFields
+}
+
+// ValidateSecretFilesForm returns nil if the given number of the files can be attached to the secret with the given options.
+// The files are encrypted by the server, so they cannot be attached to the secrets, which the server cannot encrypt.
+func ValidateSecretFilesForm(files, sharesTotal int, recipientPublicKey string, isClientEncrypted bool) (errorFields []*messages.ErrorField) {
+	// Check if the secret has no files.
+	if files == 0 {
+		return nil
+	}
+
+	// Check if the secret is encrypted in the browser, split into shares or encrypted to the recipient.
+	if isClientEncrypted || sharesTotal > 0 || recipientPublicKey != "" {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Files",
+				Message: messages.ErrFormAddSecretFilesNotCompatible,
+			},
+		)
+	}
+
 	return errorFields
 }
 
@@ -73,6 +279,39 @@ func ValidateViewSecretForm(accessCode string) (errorFields []*messages.ErrorFie
 	return errorFields
 }
 
+// ValidateBulkSecretsForm returns nil if the given bulk action and the keys of the selected secrets are valid.
+// The keys are not required for the purge of all expired secrets.
+func ValidateBulkSecretsForm(action string, keys []string) (errorFields []*messages.ErrorField) {
+	switch action {
+	case constants.ConstBulkActionPurgeExpired:
+		return nil
+	case constants.ConstBulkActionExpire, constants.ConstBulkActionRenew, constants.ConstBulkActionDelete:
+	default:
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Action",
+				Message: messages.ErrFormBulkActionNotValid,
+			},
+		)
+	}
+
+	// Check if the number of the selected secrets is not valid.
+	if len(keys) == 0 || len(keys) > constants.ConstBulkActionMaxKeys {
+		// Append error field.
+		errorFields = append(
+			errorFields,
+			&messages.ErrorField{
+				Name:    "Secrets",
+				Message: fmt.Sprintf(messages.ErrFormBulkKeysNotValid, constants.ConstBulkActionMaxKeys),
+			},
+		)
+	}
+
+	return errorFields
+}
+
 // ValidateUserSignInForm returns nil if the given user sign in form values are valid.
 func ValidateUserSignInForm(username, masterPassword string) (errorFields []*messages.ErrorField) {
 	// Check if the username is empty or not valid (length should be greater than 4 and less than 16).
@@ -1,10 +1,34 @@
 package helpers
 
 import (
+	"fmt"
 	"os"
+	"strings"
+
+	"github.com/secretium/secretium/internal/messages"
 )
 
+// configVariables is the list of the configuration variables, which can be read from the files
+// with the paths in the variables with the "_FILE" suffix (for example, Docker secrets).
+var configVariables = []string{
+	"KEY_PROVIDER", "SECRET_KEY", "SECRET_KEYS_PREVIOUS",
+	"MASTER_USERNAME", "MASTER_PASSWORD",
+	"DOMAIN", "DOMAIN_SCHEMA",
+	"SERVER_PORT", "SERVER_TIMEZONE", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT",
+	"ACCESS_CODE_TYPE", "ACCESS_CODE_LENGTH", "ACCESS_CODE_CHARACTER_CLASSES", "ACCESS_CODE_EXCLUDE_AMBIGUOUS",
+	"ACCESS_CODE_WORDS", "ACCESS_CODE_WORD_SEPARATOR",
+	"VAULT_ADDR", "VAULT_TOKEN", "VAULT_TRANSIT_MOUNT", "VAULT_TRANSIT_KEY",
+	"SECRET_MIN_TTL", "SECRET_MAX_TTL", "SECRET_MAX_LIFETIME",
+	"FAILED_ATTEMPTS_LIMIT", "FAILED_ATTEMPTS_ACTION",
+	"SECRET_FILES_MAX_SIZE",
+	"JANITOR_MODE", "JANITOR_RETENTION_PERIOD", "JANITOR_INTERVAL",
+}
+
 // Getenv returns the value of the environment variable associated with the given key.
+// If the environment variable does not exist, the value is read from the file with the path
+// in the environment variable with the "_FILE" suffix (for example, SECRET_KEY_FILE for SECRET_KEY).
+// It panics, if the file cannot be read, instead of falling back to the default value
+// (the ValidateConfigFiles function returns this error before the configuration is loaded).
 func Getenv(key, fallback string) string {
 	// Check if the environment variable exists for the given key
 	value, ok := os.LookupEnv(key)
@@ -13,6 +37,51 @@ func Getenv(key, fallback string) string {
 		return value
 	}
 
+	// Check if the environment variable with the path to the file exists for the given key
+	if path, ok := os.LookupEnv(key + "_FILE"); ok {
+		// Read the file, the unreadable file is never replaced by the fallback value
+		value, err := readConfigFile(path)
+		if err != nil {
+			panic(fmt.Errorf(messages.ErrConfigVariableFileNotReadable, key+"_FILE", err))
+		}
+
+		return value
+	}
+
 	// If the environment variable does not exist, return the fallback value
 	return fallback
 }
+
+// ValidateConfigFiles returns nil if the files of the configuration variables with the "_FILE" suffix
+// are readable, and the variables are not set in both ways.
+func ValidateConfigFiles() error {
+	for _, key := range configVariables {
+		// Check if the environment variable with the path to the file exists for the given key.
+		path, ok := os.LookupEnv(key + "_FILE")
+		if !ok {
+			continue
+		}
+
+		// Check if the variable is also set directly.
+		if _, ok := os.LookupEnv(key); ok {
+			return fmt.Errorf(messages.ErrConfigVariableFileConflict, key, key+"_FILE")
+		}
+
+		// Check if the file is readable.
+		if _, err := readConfigFile(path); err != nil {
+			return fmt.Errorf(messages.ErrConfigVariableFileNotReadable, key+"_FILE", err)
+		}
+	}
+
+	return nil
+}
+
+// readConfigFile returns the content of the given configuration file without the trailing newlines.
+func readConfigFile(path string) (string, error) {
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
@@ -0,0 +1,92 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestGetenv(t *testing.T) {
+	// Test reading the variable from the file with a trailing newline
+	path := filepath.Join(t.TempDir(), "secretium_master_password.txt")
+	if err := os.WriteFile(path, []byte("my-master-password\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("MASTER_PASSWORD_FILE", path)
+
+	if value := Getenv("MASTER_PASSWORD", ""); value != "my-master-password" {
+		t.Errorf("unexpected value, got: %v, want: %v", value, "my-master-password")
+	}
+
+	if err := ValidateConfigFiles(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Test setting the variable both directly and with the file
+	t.Setenv("MASTER_PASSWORD", "my-other-password")
+
+	if value := Getenv("MASTER_PASSWORD", ""); value != "my-other-password" {
+		t.Errorf("unexpected value, got: %v, want: %v", value, "my-other-password")
+	}
+
+	if err := ValidateConfigFiles(); err == nil {
+		t.Errorf("expected error for the variable set in both ways, got: nil")
+	}
+
+	// Test reading the variable from the missing file
+	t.Setenv("MASTER_USERNAME_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+
+	if err := ValidateConfigFiles(); err == nil {
+		t.Errorf("expected error for the missing file, got: nil")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic for the missing file, got: nil")
+			}
+		}()
+
+		value := Getenv("MASTER_USERNAME", "fallback")
+		t.Errorf("unexpected value, got: %v, want: panic", value)
+	}()
+}
+
+func TestConfigVariables(t *testing.T) {
+	// Collect the names of the variables, which are read by the config and its validation
+	files, err := filepath.Glob(filepath.Join("..", "config", "*.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	files = append(files, "config_validator.go")
+
+	getenv := regexp.MustCompile(`(?:helpers\.|[^.\w])Getenv\("([A-Z0-9_]+)"`)
+	var variables []string
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, match := range getenv.FindAllStringSubmatch(string(source), -1) {
+			if !slices.Contains(variables, match[1]) {
+				variables = append(variables, match[1])
+			}
+		}
+	}
+
+	// Test, that every variable can be read from the file, and the list has no unknown variables
+	for _, variable := range variables {
+		if !slices.Contains(configVariables, variable) {
+			t.Errorf("unexpected missing variable %q in the list of the configuration variables", variable)
+		}
+	}
+
+	for _, variable := range configVariables {
+		if !slices.Contains(variables, variable) {
+			t.Errorf("unexpected variable %q in the list of the configuration variables, which is not read by the config", variable)
+		}
+	}
+}
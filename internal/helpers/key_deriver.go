@@ -0,0 +1,26 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/secretium/secretium/internal/constants"
+)
+
+// DeriveKey derives a new 32-byte subkey from the given secret key for the given purpose.
+// It uses HKDF with SHA256, so the subkeys of different purposes are independent of each other
+// and any secret key length is supported.
+func DeriveKey(secretKey, purpose string) ([]byte, error) {
+	// Create a new HKDF reader with the salt and the purpose as the info.
+	r := hkdf.New(sha256.New, []byte(secretKey), []byte(constants.ConstEncryptionKeyDerivationSalt), []byte(purpose))
+
+	// Read the subkey from the HKDF reader.
+	key := make([]byte, constants.ConstEncryptionKeySize)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
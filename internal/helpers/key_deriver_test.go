@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeriveKey(t *testing.T) {
+	// Test deriving subkeys from secret keys of any length
+	for _, secretKey := range []string{"0123456789abcdef", "0123456789abcdef0123", "0123456789abcdef0123456789abcdef01234567"} {
+		valueKey, err := DeriveKey(secretKey, "value")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(valueKey) != 32 {
+			t.Errorf("unexpected subkey length, got: %v, want: %v", len(valueKey), 32)
+		}
+
+		if _, err := EncryptString(valueKey, "text"); err != nil {
+			t.Errorf("unexpected error for secret key length %d: %v", len(secretKey), err)
+		}
+
+		// Test deriving the same subkey for the same purpose
+		sameKey, _ := DeriveKey(secretKey, "value")
+		if !bytes.Equal(valueKey, sameKey) {
+			t.Errorf("unexpected different subkeys for the same purpose")
+		}
+
+		// Test deriving different subkeys for different purposes
+		accessCodeKey, _ := DeriveKey(secretKey, "access-code")
+		if bytes.Equal(valueKey, accessCodeKey) {
+			t.Errorf("unexpected equal subkeys for different purposes")
+		}
+	}
+}
+
+func TestDeriveAccessCodeKey(t *testing.T) {
+	key, encodedHash, err := DeriveAccessCodeKey("a1b2c3d4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Test verifying the correct access code
+	verifiedKey, err := VerifyAccessCodeKey("a1b2c3d4", encodedHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(key, verifiedKey) {
+		t.Errorf("unexpected different keys for the same access code")
+	}
+
+	// Test verifying a wrong access code
+	if _, err := VerifyAccessCodeKey("a1b2c3d5", encodedHash); err == nil {
+		t.Errorf("unexpected nil error for a wrong access code")
+	}
+
+	// Test verifying a malformed hash
+	if _, err := VerifyAccessCodeKey("a1b2c3d4", "$argon2id$v=19$m=1$salt"); err == nil {
+		t.Errorf("unexpected nil error for a malformed hash")
+	}
+}
+
+func TestHashAccessCode(t *testing.T) {
+	encodedHash, err := HashAccessCode("a1b2c3d4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !IsAccessCodeHash(encodedHash) {
+		t.Errorf("unexpected hash format, got: %v", encodedHash)
+	}
+
+	// Test verifying the correct and wrong access codes
+	if err := VerifyAccessCodeHash("a1b2c3d4", encodedHash); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := VerifyAccessCodeHash("a1b2c3d5", encodedHash); err == nil {
+		t.Errorf("unexpected nil error for a wrong access code")
+	}
+}
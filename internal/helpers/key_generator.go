@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/secretium/secretium/internal/constants"
+)
+
+// GenerateDataKey generates a new random 32-byte data key.
+func GenerateDataKey() ([]byte, error) {
+	// Read the random bytes for the key.
+	key := make([]byte, constants.ConstEncryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GenerateRandomString generates a new random hex string with the given size.
+func GenerateRandomString(size int) (string, error) {
+	// Read the random bytes for the string.
+	b := make([]byte, (size+1)/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b)[:size], nil
+}
@@ -0,0 +1,16 @@
+package helpers
+
+import "strings"
+
+// SplitList splits a comma-separated list into a slice of trimmed and non-empty values.
+func SplitList(list string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(list, ",") {
+		// Skip empty values.
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// EncryptToRecipient encrypts the given value to the given public key of the recipient (an age X25519 recipient or
+// an armored OpenPGP public key), so that only the recipient can decrypt it with the private key.
+// It returns the type of the recipient, the recipient (the age recipient or the OpenPGP key fingerprint),
+// and the armored ciphertext.
+func EncryptToRecipient(publicKey, value string) (recipientType, recipient, ciphertext string, err error) {
+	publicKey = strings.TrimSpace(publicKey)
+
+	// Check the type of the public key.
+	switch {
+	case strings.HasPrefix(publicKey, "age1"):
+		ciphertext, err = encryptToAgeRecipient(publicKey, value)
+		if err != nil {
+			return "", "", "", err
+		}
+
+		return constants.ConstRecipientTypeAge, publicKey, ciphertext, nil
+	case strings.HasPrefix(publicKey, "-----BEGIN "+openpgp.PublicKeyType+"-----"):
+		recipient, ciphertext, err = encryptToOpenPGPRecipient(publicKey, value)
+		if err != nil {
+			return "", "", "", err
+		}
+
+		return constants.ConstRecipientTypeOpenPGP, recipient, ciphertext, nil
+	default:
+		return "", "", "", errors.New(messages.ErrRecipientPublicKeyNotValid)
+	}
+}
+
+// encryptToAgeRecipient encrypts the given value to the given age X25519 recipient, and returns the armored ciphertext.
+func encryptToAgeRecipient(publicKey, value string) (string, error) {
+	// Parse the age recipient.
+	recipient, err := age.ParseX25519Recipient(publicKey)
+	if err != nil {
+		return "", errors.New(messages.ErrRecipientPublicKeyNotValid)
+	}
+
+	// Encrypt the value to the recipient with the ASCII armor.
+	var buffer bytes.Buffer
+	armorWriter := armor.NewWriter(&buffer)
+	writer, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(writer, value); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// encryptToOpenPGPRecipient encrypts the given value to the given armored OpenPGP public key, and returns
+// the fingerprint of the key and the armored ciphertext.
+func encryptToOpenPGPRecipient(publicKey, value string) (fingerprint, ciphertext string, err error) {
+	// Parse the OpenPGP public key (only one key is allowed).
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil || len(entities) != 1 {
+		return "", "", errors.New(messages.ErrRecipientPublicKeyNotValid)
+	}
+
+	// Encrypt the value to the key with the ASCII armor.
+	var buffer bytes.Buffer
+	armorWriter, err := pgparmor.Encode(&buffer, "PGP MESSAGE", nil)
+	if err != nil {
+		return "", "", err
+	}
+	writer, err := openpgp.Encrypt(armorWriter, entities, nil, nil, nil)
+	if err != nil {
+		// The key has no valid encryption subkey (for example, it is expired or revoked).
+		return "", "", errors.New(messages.ErrRecipientPublicKeyNotValid)
+	}
+	if _, err := io.WriteString(writer, value); err != nil {
+		return "", "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", "", err
+	}
+
+	return fmt.Sprintf("%X", entities[0].PrimaryKey.Fingerprint), buffer.String() + "\n", nil
+}
@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/secretium/secretium/internal/constants"
+)
+
+func TestEncryptToRecipient(t *testing.T) {
+	value := "my secret value"
+
+	// Test encrypting to the age recipient
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recipientType, recipient, ciphertext, err := EncryptToRecipient(identity.Recipient().String(), value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recipientType != constants.ConstRecipientTypeAge || recipient != identity.Recipient().String() {
+		t.Errorf("unexpected recipient, got: %v %v, want: %v %v", recipientType, recipient, constants.ConstRecipientTypeAge, identity.Recipient())
+	}
+
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(ciphertext)), identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decrypted, _ := io.ReadAll(reader); string(decrypted) != value {
+		t.Errorf("unexpected decrypted value, got: %v, want: %v", string(decrypted), value)
+	}
+
+	// Test encrypting to the OpenPGP public key
+	entity, err := openpgp.NewEntity("Friend", "", "friend@example.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var publicKey bytes.Buffer
+	armorWriter, err := pgparmor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := entity.Serialize(armorWriter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = armorWriter.Close()
+
+	recipientType, _, ciphertext, err = EncryptToRecipient(publicKey.String(), value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recipientType != constants.ConstRecipientTypeOpenPGP {
+		t.Errorf("unexpected recipient type, got: %v, want: %v", recipientType, constants.ConstRecipientTypeOpenPGP)
+	}
+
+	block, err := pgparmor.Decode(strings.NewReader(ciphertext))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decrypted, _ := io.ReadAll(message.UnverifiedBody); string(decrypted) != value {
+		t.Errorf("unexpected decrypted value, got: %v, want: %v", string(decrypted), value)
+	}
+
+	// Test encrypting to the invalid public keys
+	for _, publicKey := range []string{"", "ssh-ed25519 AAAA", "age1invalid", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\ninvalid"} {
+		if _, _, _, err := EncryptToRecipient(publicKey, value); err == nil {
+			t.Errorf("unexpected nil error for the public key: %q", publicKey)
+		}
+	}
+}
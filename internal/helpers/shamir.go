@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// gf256Exp and gf256Log are the exponent and logarithm tables of GF(2^8) with the AES polynomial (x^8 + x^4 + x^3 + x + 1)
+// and the generator 3.
+var gf256Exp, gf256Log = func() (exp [510]byte, log [256]byte) {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i], exp[i+255] = x, x
+		log[x] = byte(i)
+
+		// Multiply x by the generator 3 (x*2 + x).
+		x2 := x << 1
+		if x&0x80 != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+
+	return exp, log
+}()
+
+// gf256Mul returns the product of a and b in GF(2^8).
+func gf256Mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gf256Exp[int(gf256Log[a])+int(gf256Log[b])]
+}
+
+// gf256Div returns the quotient of a and b (b is not zero) in GF(2^8).
+func gf256Div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gf256Exp[int(gf256Log[a])+255-int(gf256Log[b])]
+}
+
+// SplitSecret splits the given secret into n shares with Shamir's Secret Sharing, so that any threshold
+// of the shares can be combined back with the CombineShares function, but fewer shares reveal nothing.
+// Each share is the x coordinate (from 1 to n) followed by the y coordinates for each byte of the secret.
+func SplitSecret(secret []byte, n, threshold int) ([][]byte, error) {
+	// Check the number of shares and the threshold.
+	if len(secret) == 0 || threshold < 2 || threshold > n || n > 255 {
+		return nil, errors.New(messages.ErrSecretSharesNotValid)
+	}
+
+	// Create the shares with their x coordinates.
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][0] = byte(i + 1)
+	}
+
+	// Create a random polynomial for each byte of the secret (the secret byte is the constant term).
+	coefficients := make([]byte, threshold)
+	for j, b := range secret {
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+		coefficients[0] = b
+
+		// Evaluate the polynomial at the x coordinate of each share (Horner's method).
+		for _, share := range shares {
+			y := byte(0)
+			for k := threshold - 1; k >= 0; k-- {
+				y = gf256Mul(y, share[0]) ^ coefficients[k]
+			}
+			share[j+1] = y
+		}
+	}
+
+	// Clear the random coefficients.
+	clear(coefficients)
+
+	return shares, nil
+}
+
+// CombineShares combines the given shares (created by the SplitSecret function) back into the secret
+// with Lagrange interpolation. The number of shares must be at least the threshold of the split.
+func CombineShares(shares [][]byte) ([]byte, error) {
+	// Check the shares have the same length and different x coordinates.
+	if len(shares) < 2 {
+		return nil, errors.New(messages.ErrSecretSharesNotValid)
+	}
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if len(share) < 2 || len(share) != len(shares[0]) || share[0] == 0 || seen[share[0]] {
+			return nil, errors.New(messages.ErrSecretSharesNotValid)
+		}
+		seen[share[0]] = true
+	}
+
+	// Interpolate the polynomial of each byte of the secret at x = 0.
+	secret := make([]byte, len(shares[0])-1)
+	for j := range secret {
+		for i, share := range shares {
+			// Calculate the Lagrange basis polynomial of the share at x = 0.
+			basis := byte(1)
+			for k, other := range shares {
+				if k != i {
+					basis = gf256Mul(basis, gf256Div(other[0], other[0]^share[0]))
+				}
+			}
+			secret[j] ^= gf256Mul(share[j+1], basis)
+		}
+	}
+
+	return secret, nil
+}
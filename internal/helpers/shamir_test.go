@@ -0,0 +1,51 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitSecret(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	shares, err := SplitSecret(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(shares) != 5 {
+		t.Errorf("unexpected number of shares, got: %v, want: %v", len(shares), 5)
+	}
+
+	// Test combining any 3 or more shares
+	for _, subset := range [][][]byte{
+		{shares[0], shares[1], shares[2]},
+		{shares[4], shares[2], shares[0]},
+		{shares[1], shares[3], shares[4]},
+		shares,
+	} {
+		combined, err := CombineShares(subset)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !bytes.Equal(combined, secret) {
+			t.Errorf("unexpected combined secret, got: %x, want: %x", combined, secret)
+		}
+	}
+
+	// Test combining fewer shares than the threshold
+	if combined, _ := CombineShares(shares[:2]); bytes.Equal(combined, secret) {
+		t.Errorf("unexpected combined secret from 2 shares")
+	}
+
+	// Test combining the duplicated shares
+	if _, err := CombineShares([][]byte{shares[0], shares[0], shares[1]}); err == nil {
+		t.Errorf("unexpected nil error for the duplicated shares")
+	}
+
+	// Test splitting with the invalid threshold
+	if _, err := SplitSecret(secret, 3, 4); err == nil {
+		t.Errorf("unexpected nil error for the threshold greater than the number of shares")
+	}
+}
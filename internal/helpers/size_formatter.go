@@ -0,0 +1,25 @@
+package helpers
+
+import "fmt"
+
+// FormatSize returns a human-readable size in bytes, KiB or MiB (with one decimal place, if it is not whole).
+func FormatSize(size int64) string {
+	// Get the largest unit of the size.
+	switch {
+	case size >= 1024*1024:
+		return formatSizeUnit(size, 1024*1024, "MiB")
+	case size >= 1024:
+		return formatSizeUnit(size, 1024, "KiB")
+	}
+
+	return fmt.Sprintf("%d B", size)
+}
+
+// formatSizeUnit returns the size in the given unit.
+func formatSizeUnit(size, unit int64, name string) string {
+	if size%unit == 0 {
+		return fmt.Sprintf("%d %s", size/unit, name)
+	}
+
+	return fmt.Sprintf("%.1f %s", float64(size)/float64(unit), name)
+}
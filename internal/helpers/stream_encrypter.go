@@ -0,0 +1,155 @@
+package helpers
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// streamVersion is the version byte of the encrypted stream header.
+const streamVersion byte = 1
+
+// streamNoncePrefixSize is the size of the random nonce prefix in the encrypted stream header.
+// The rest of the 12-byte nonce is the big-endian chunk counter (4 bytes) and the last chunk flag (1 byte).
+const streamNoncePrefixSize int = 7
+
+// EncryptStream encrypts the data from the given reader with AES-GCM and the given 32-byte key in chunks, and writes
+// the encrypted stream to the given writer. The stream starts with a header (the version and a random nonce prefix),
+// and each chunk is sealed with a nonce of the prefix, the chunk counter and the last chunk flag, so the chunks cannot
+// be reordered, and the truncated stream is detected on decryption. It returns the size of the encrypted data.
+func EncryptStream(key []byte, dst io.Writer, src io.Reader) (int64, error) {
+	// Create a new AES-GCM cipher using the key.
+	aead, err := newGCM(key)
+	if err != nil {
+		return 0, err
+	}
+
+	// Generate a random nonce prefix.
+	header := make([]byte, 1+streamNoncePrefixSize)
+	header[0] = streamVersion
+	if _, err := rand.Read(header[1:]); err != nil {
+		return 0, err
+	}
+
+	// Write the header of the stream.
+	if _, err := dst.Write(header); err != nil {
+		return 0, err
+	}
+
+	// Seal the data chunk by chunk.
+	reader := bufio.NewReaderSize(src, constants.ConstSecretFilesChunkSize)
+	chunk := make([]byte, constants.ConstSecretFilesChunkSize, constants.ConstSecretFilesChunkSize+aead.Overhead())
+	var size int64
+	for counter := uint32(0); ; counter++ {
+		// Read the next chunk, and check, if it is the last one.
+		n, last, err := readStreamChunk(reader, chunk[:constants.ConstSecretFilesChunkSize])
+		if err != nil {
+			return size, err
+		}
+		size += int64(n)
+
+		// Seal the chunk and write it to the stream.
+		if _, err := dst.Write(aead.Seal(chunk[:0], streamNonce(header[1:], counter, last), chunk[:n], nil)); err != nil {
+			return size, err
+		}
+
+		if last {
+			return size, nil
+		}
+
+		// Check, if the chunk counter is not overflowed.
+		if counter == ^uint32(0) {
+			return size, errors.New(messages.ErrEncryptedStreamNotValid)
+		}
+	}
+}
+
+// DecryptStream decrypts the stream, which was encrypted by the EncryptStream function with the given 32-byte key,
+// from the given reader, and writes the data to the given writer chunk by chunk. It returns an error, if the stream
+// is malformed, truncated or was tampered with (the chunks before the broken one are already written).
+// It returns the size of the decrypted data.
+func DecryptStream(key []byte, dst io.Writer, src io.Reader) (int64, error) {
+	// Create a new AES-GCM cipher using the key.
+	aead, err := newGCM(key)
+	if err != nil {
+		return 0, err
+	}
+
+	// Read the header of the stream.
+	header := make([]byte, 1+streamNoncePrefixSize)
+	if _, err := io.ReadFull(src, header); err != nil || header[0] != streamVersion {
+		return 0, errors.New(messages.ErrEncryptedStreamNotValid)
+	}
+
+	// Open the data chunk by chunk.
+	reader := bufio.NewReaderSize(src, constants.ConstSecretFilesChunkSize+aead.Overhead())
+	chunk := make([]byte, constants.ConstSecretFilesChunkSize+aead.Overhead())
+	var size int64
+	for counter := uint32(0); ; counter++ {
+		// Read the next sealed chunk, and check, if it is the last one.
+		n, last, err := readStreamChunk(reader, chunk)
+		if err != nil {
+			return size, err
+		}
+
+		// Open the chunk (the last flag of the nonce must match the end of the stream).
+		plaintext, err := aead.Open(chunk[:0], streamNonce(header[1:], counter, last), chunk[:n], nil)
+		if err != nil {
+			return size, errors.New(messages.ErrEncryptedStreamNotValid)
+		}
+
+		// Write the decrypted chunk.
+		if _, err := dst.Write(plaintext); err != nil {
+			return size, err
+		}
+		size += int64(len(plaintext))
+
+		if last {
+			return size, nil
+		}
+
+		// Check, if the chunk counter is not overflowed.
+		if counter == ^uint32(0) {
+			return size, errors.New(messages.ErrEncryptedStreamNotValid)
+		}
+	}
+}
+
+// readStreamChunk fills the given chunk from the reader, and returns the number of the read bytes.
+// The chunk is the last one, if the reader has no more data after it.
+func readStreamChunk(reader *bufio.Reader, chunk []byte) (n int, last bool, err error) {
+	// Read the chunk (the short chunk is always the last one).
+	n, err = io.ReadFull(reader, chunk)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, true, nil
+	}
+	if err != nil {
+		return n, false, err
+	}
+
+	// Check, if there is more data after the full chunk.
+	if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+
+	return n, false, nil
+}
+
+// streamNonce returns the nonce of the chunk with the given counter from the nonce prefix and the last chunk flag.
+func streamNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, streamNoncePrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+
+	return nonce
+}
@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/secretium/secretium/internal/constants"
+)
+
+func TestEncryptDecryptStream(t *testing.T) {
+	key, _ := DeriveKey("this-is-my-secret-key-123", "test")
+	wrongKey, _ := DeriveKey("this-is-my-secret-key-123", "wrong")
+	chunkSize := constants.ConstSecretFilesChunkSize
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize} {
+		data := make([]byte, size)
+		_, _ = rand.Read(data)
+
+		// Test encrypting and decrypting a stream
+		var encrypted bytes.Buffer
+		written, err := EncryptStream(key, &encrypted, bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if written != int64(size) {
+			t.Errorf("unexpected encrypted size, got: %v, want: %v", written, size)
+		}
+
+		var decrypted bytes.Buffer
+		read, err := DecryptStream(key, &decrypted, bytes.NewReader(encrypted.Bytes()))
+		if err != nil {
+			t.Fatalf("unexpected error for size %d: %v", size, err)
+		}
+
+		if read != int64(size) || !bytes.Equal(decrypted.Bytes(), data) {
+			t.Errorf("unexpected decrypted data for size %d, got: %v bytes, want: %v bytes", size, read, size)
+		}
+
+		// Test decrypting a tampered stream
+		tampered := bytes.Clone(encrypted.Bytes())
+		tampered[len(tampered)-1] ^= 1
+		if _, err := DecryptStream(key, &bytes.Buffer{}, bytes.NewReader(tampered)); err == nil {
+			t.Errorf("expected error for tampered stream of size %d, got: nil", size)
+		}
+
+		// Test decrypting a stream truncated after the first chunk
+		if size > chunkSize {
+			truncated := encrypted.Bytes()[:1+streamNoncePrefixSize+chunkSize+16]
+			if _, err := DecryptStream(key, &bytes.Buffer{}, bytes.NewReader(truncated)); err == nil {
+				t.Errorf("expected error for truncated stream of size %d, got: nil", size)
+			}
+		}
+
+		// Test decrypting a stream with the appended data
+		appended := append(bytes.Clone(encrypted.Bytes()), 0)
+		if _, err := DecryptStream(key, &bytes.Buffer{}, bytes.NewReader(appended)); err == nil {
+			t.Errorf("expected error for appended stream of size %d, got: nil", size)
+		}
+
+		// Test decrypting with a wrong key
+		if _, err := DecryptStream(wrongKey, &bytes.Buffer{}, bytes.NewReader(encrypted.Bytes())); err == nil {
+			t.Errorf("expected error for wrong key, got: nil")
+		}
+	}
+
+	// Test decrypting a stream without the header
+	if _, err := DecryptStream(key, &bytes.Buffer{}, bytes.NewReader(nil)); err == nil {
+		t.Errorf("expected error for empty stream, got: nil")
+	}
+}
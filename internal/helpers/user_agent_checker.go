@@ -0,0 +1,31 @@
+package helpers
+
+import "strings"
+
+// unfurlerUserAgents is the list of the lowercase bot tokens of the user agents of the known link unfurlers
+// (chat and social media bots, which fetch the shared links to show their previews) and crawlers.
+// The generic words and the app names are not listed, because the in-app browsers of the apps send them too.
+var unfurlerUserAgents = []string{
+	"slackbot", "slack-imgproxy", "twitterbot", "facebookexternalhit", "facebot", "linkedinbot",
+	"whatsapp/", "telegrambot", "discordbot", "skypeuripreview", "microsoftpreview",
+	"mattermost-bot", "zulipurlpreview", "snap url preview service", "redditbot", "pinterestbot",
+	"embedly", "iframely", "vkshare", "applebot", "googlebot", "bingbot", "yandexbot", "duckduckbot",
+	"bitlybot", "google-pagerenderer",
+}
+
+// IsUnfurlerUserAgent returns true, if the given user agent belongs to the known link unfurler or crawler.
+func IsUnfurlerUserAgent(userAgent string) bool {
+	// Check, if the user agent is empty (the browsers always send it).
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+
+	for _, part := range unfurlerUserAgents {
+		if strings.Contains(userAgent, part) {
+			return true
+		}
+	}
+
+	return false
+}
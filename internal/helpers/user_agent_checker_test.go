@@ -0,0 +1,30 @@
+package helpers
+
+import "testing"
+
+func TestIsUnfurlerUserAgent(t *testing.T) {
+	for userAgent, want := range map[string]bool{
+		"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)":                                            true,
+		"Mozilla/5.0 (compatible; Discordbot/2.0; +https://discordapp.com)":                                     true,
+		"TelegramBot (like TwitterBot)":                                                                         true,
+		"WhatsApp/2.23.20.0":                                                                                    true,
+		"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)":                             true,
+		"Mozilla/5.0 (Windows NT 6.1; WOW64) SkypeUriPreview Preview/0.5":                                       true,
+		"Mozilla/5.0 (compatible; Snap URL Preview Service; bot; snapchat; https://developers.snap.com/robots)": true,
+		"mattermost-bot/1.1 (+https://mattermost.com/)":                                                         true,
+		"Mozilla/5.0 (compatible; ZulipURLPreview/8.4; +https://chat.zulip.org)":                                true,
+		"": true,
+		"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0":                                                                                               false,
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) AppleWebKit/605.1.15 (KHTML, like Gecko) Safari/605":                                                                     false,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Snapchat/13.10.0.44 (like Safari/8617.2.4.10.8, panda)": false,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36 Viber/22.5.0.3":                                 false,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Mattermost/5.8.1 Chrome/122.0.6261.156 Electron/29.3.0 Safari/537.36":                false,
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Rocket.Chat/4.0.2 Chrome/122.0.6261.130 Electron/29.1.4 Safari/537.36":         false,
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) ZulipElectron/5.11.0 Chrome/122.0.6261.156 Electron/29.3.0 Safari/537.36":                      false,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 ZulipMobile/27.223 (iOS 17.4)":                          false,
+	} {
+		if got := IsUnfurlerUserAgent(userAgent); got != want {
+			t.Errorf("unexpected result for %q, got: %v, want: %v", userAgent, got, want)
+		}
+	}
+}
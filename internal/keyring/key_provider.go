@@ -0,0 +1,92 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// KeyProvider encrypts and decrypts the small texts (the wrapped data keys and the legacy access codes)
+// with a secret key, which is either derived in the process memory or kept by an external service.
+type KeyProvider interface {
+	// ID returns the key ID, which is written to the envelope of the encrypted texts.
+	ID() string
+
+	// Encrypt encrypts the given text with the key for the given purpose.
+	Encrypt(purpose, text string) (string, error)
+
+	// Decrypt decrypts the given encrypted text with the key for the given purpose.
+	Decrypt(purpose, encryptedText string) (string, error)
+}
+
+// Key contains the key ID and purpose-separated subkeys derived from one secret key in the process memory.
+type Key struct {
+	id        string
+	secretKey string
+	subkeys   map[string][]byte
+}
+
+// NewEnvKeyProvider returns a new key provider with the given secret key, which is read from the environment variable
+// (or from the file with the path in the environment variable with the "_FILE" suffix) by the config.
+func NewEnvKeyProvider(secretKey string) (*Key, error) {
+	return newKey(secretKey)
+}
+
+// ID returns the key ID derived from the secret key.
+func (k *Key) ID() string {
+	return k.id
+}
+
+// Encrypt encrypts the given text with the subkey for the given purpose.
+func (k *Key) Encrypt(purpose, text string) (string, error) {
+	return helpers.EncryptStringWithKeyID(k.id, k.subkeys[purpose], text)
+}
+
+// Decrypt decrypts the given encrypted text with the subkey for the given purpose.
+// The encrypted texts in the legacy format are decrypted with the secret key.
+func (k *Key) Decrypt(purpose, encryptedText string) (string, error) {
+	// Check, if the encrypted text is in the legacy format.
+	if helpers.IsEncryptedStringLegacy(encryptedText) {
+		return helpers.DecryptLegacyString(k.secretKey, encryptedText)
+	}
+
+	return helpers.DecryptString(k.subkeys[purpose], encryptedText)
+}
+
+// newKey returns a new key with the key ID and subkeys derived from the given secret key.
+func newKey(secretKey string) (*Key, error) {
+	// Check the length of the secret key.
+	if len(secretKey) < constants.ConstConfigSecretKeyMinLength {
+		return nil, fmt.Errorf(messages.ErrConfigSecretKeyLengthNotValid, constants.ConstConfigSecretKeyMinLength)
+	}
+
+	key := &Key{
+		secretKey: secretKey,
+		subkeys:   make(map[string][]byte),
+	}
+
+	// Derive the key ID from the secret key.
+	keyID, err := helpers.DeriveKey(secretKey, constants.ConstEncryptionKeyPurposeKeyID)
+	if err != nil {
+		return nil, err
+	}
+	key.id = hex.EncodeToString(keyID)[:constants.ConstEncryptionKeyIDLength]
+
+	// Derive the purpose-separated subkeys from the secret key.
+	for _, purpose := range []string{
+		constants.ConstEncryptionKeyPurposeValue,
+		constants.ConstEncryptionKeyPurposeAccessCode,
+		constants.ConstEncryptionKeyPurposeDataKey,
+	} {
+		subkey, err := helpers.DeriveKey(secretKey, purpose)
+		if err != nil {
+			return nil, err
+		}
+		key.subkeys[purpose] = subkey
+	}
+
+	return key, nil
+}
@@ -0,0 +1,106 @@
+package keyring
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/secretium/secretium/internal/config"
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/helpers"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// Keyring contains the active key provider for encryption and the previous key providers,
+// which are still accepted for decryption.
+type Keyring struct {
+	Keys []KeyProvider
+}
+
+// New returns a new instance of Keyring with the active key provider from the config, and the previous secret keys.
+func New(c *config.Config) (*Keyring, error) {
+	// Create the active key provider.
+	var active KeyProvider
+	var err error
+	switch c.KeyProvider {
+	case constants.ConstKeyProviderVault:
+		active, err = NewVaultTransitKeyProvider(c.Vault.Address, c.Vault.Token, c.Vault.TransitMount, c.Vault.TransitKey)
+	default:
+		// The secret key of the file key provider is read from SECRET_KEY_FILE by the config, as any other "_FILE" variable.
+		active, err = NewEnvKeyProvider(c.SecretKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new keyring with the active key provider first.
+	keyring := &Keyring{Keys: []KeyProvider{active}}
+	for _, secretKey := range c.PreviousSecretKeys {
+		key, err := newKey(secretKey)
+		if err != nil {
+			return nil, err
+		}
+		keyring.Keys = append(keyring.Keys, key)
+	}
+
+	return keyring, nil
+}
+
+// Active returns the active key provider of the keyring.
+func (k *Keyring) Active() KeyProvider {
+	return k.Keys[0]
+}
+
+// Encrypt encrypts the given text with the active key provider for the given purpose.
+func (k *Keyring) Encrypt(purpose, text string) (string, error) {
+	return k.Active().Encrypt(purpose, text)
+}
+
+// Decrypt decrypts the given encrypted text for the given purpose.
+// The key provider is selected by the key ID of the encrypted text, and the encrypted texts without a key ID
+// (in the legacy format) are decrypted with the first key provider of the keyring, which fits.
+func (k *Keyring) Decrypt(purpose, encryptedText string) (string, error) {
+	// Check, if the encrypted text has a key ID.
+	if keyID, found := helpers.ParseEncryptedStringKeyID(encryptedText); found {
+		for _, key := range k.Keys {
+			if key.ID() == keyID {
+				return key.Decrypt(purpose, encryptedText)
+			}
+		}
+
+		return "", errors.New(messages.ErrEncryptionKeyIDNotFound)
+	}
+
+	// Try to decrypt the encrypted text in the legacy format with each key provider.
+	var err error
+	for _, key := range k.Keys {
+		var text string
+		if text, err = key.Decrypt(purpose, encryptedText); err == nil {
+			return text, nil
+		}
+	}
+
+	return "", err
+}
+
+// WrapKey encrypts the given data key with the active key provider for wrapping data keys.
+func (k *Keyring) WrapKey(key []byte) (string, error) {
+	return k.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, base64.RawStdEncoding.EncodeToString(key))
+}
+
+// UnwrapKey decrypts the given wrapped data key, which was encrypted by the WrapKey function.
+func (k *Keyring) UnwrapKey(wrappedKey string) ([]byte, error) {
+	// Decrypt the wrapped data key.
+	key, err := k.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.RawStdEncoding.DecodeString(key)
+}
+
+// IsOutdated returns true if the given encrypted text is not encrypted with the active key in the current envelope version.
+func (k *Keyring) IsOutdated(encryptedText string) bool {
+	keyID, found := helpers.ParseEncryptedStringKeyID(encryptedText)
+
+	return !found || keyID != k.Active().ID()
+}
@@ -0,0 +1,95 @@
+package keyring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secretium/secretium/internal/config"
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/helpers"
+)
+
+func TestKeyring(t *testing.T) {
+	purpose := constants.ConstEncryptionKeyPurposeValue
+
+	// Encrypt a value with the old keyring
+	oldKeyring, err := New(&config.Config{SecretKey: "this-is-my-old-secret-key"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encrypted, err := oldKeyring.Encrypt(purpose, "my secret value")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if oldKeyring.IsOutdated(encrypted) {
+		t.Errorf("unexpected outdated flag, got: %v, want: %v", true, false)
+	}
+
+	// Test decrypting the value with the new keyring, which has the old key as previous
+	newKeyring, err := New(&config.Config{
+		SecretKey:          "this-is-my-new-secret-key",
+		PreviousSecretKeys: []string{"this-is-my-old-secret-key"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if newKeyring.Active().ID() == oldKeyring.Active().ID() {
+		t.Errorf("unexpected equal key IDs for different secret keys")
+	}
+
+	if !newKeyring.IsOutdated(encrypted) {
+		t.Errorf("unexpected outdated flag, got: %v, want: %v", false, true)
+	}
+
+	decrypted, err := newKeyring.Decrypt(purpose, encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decrypted != "my secret value" {
+		t.Errorf("unexpected decrypted text, got: %v, want: %v", decrypted, "my secret value")
+	}
+
+	// Test decrypting the value without the old key
+	withoutOldKeyring, _ := New(&config.Config{SecretKey: "this-is-my-new-secret-key"})
+	if _, err := withoutOldKeyring.Decrypt(purpose, encrypted); err == nil {
+		t.Errorf("expected error for missing key ID, got: nil")
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	// Test reading the secret key from the file with a trailing newline
+	path := filepath.Join(t.TempDir(), "secret_key.txt")
+	if err := os.WriteFile(path, []byte("this-is-my-new-secret-key\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("SECRET_KEY_FILE", path)
+
+	fileKeyring, err := New(&config.Config{KeyProvider: constants.ConstKeyProviderFile, SecretKey: helpers.Getenv("SECRET_KEY", "")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	envKeyring, _ := New(&config.Config{SecretKey: "this-is-my-new-secret-key"})
+	if fileKeyring.Active().ID() != envKeyring.Active().ID() {
+		t.Errorf("unexpected key ID, got: %v, want: %v", fileKeyring.Active().ID(), envKeyring.Active().ID())
+	}
+
+	// Test reading the secret key with the trailing spaces, which are a part of the key
+	if err := os.WriteFile(path, []byte("this-is-my-new-secret-key \r\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spacedKeyring, err := New(&config.Config{KeyProvider: constants.ConstKeyProviderFile, SecretKey: helpers.Getenv("SECRET_KEY", "")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if spacedKeyring.Active().ID() == envKeyring.Active().ID() {
+		t.Errorf("unexpected equal key IDs for different secret keys")
+	}
+}
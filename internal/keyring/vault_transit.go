@@ -0,0 +1,161 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/secretium/secretium/internal/constants"
+	"github.com/secretium/secretium/internal/messages"
+)
+
+// VaultTransit is the key provider, which encrypts and decrypts with the named key of the HashiCorp Vault transit
+// secrets engine. The key never leaves Vault, only the texts to encrypt and decrypt are sent to the server.
+// See https://developer.hashicorp.com/vault/docs/secrets/transit for more details.
+type VaultTransit struct {
+	id, address, token, mount, key string
+	client                         *http.Client
+}
+
+// vaultTransitRequest represents the body of the encrypt and decrypt requests to the Vault transit engine.
+type vaultTransitRequest struct {
+	Plaintext      string `json:"plaintext,omitempty"`
+	Ciphertext     string `json:"ciphertext,omitempty"`
+	AssociatedData string `json:"associated_data"`
+}
+
+// vaultTransitResponse represents the body of the responses of the Vault transit engine.
+type vaultTransitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// NewVaultTransitKeyProvider returns a new key provider with the given key of the Vault transit engine.
+func NewVaultTransitKeyProvider(address, token, mount, key string) (*VaultTransit, error) {
+	// Check the Vault settings.
+	if u, err := url.Parse(address); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New(messages.ErrConfigVaultAddressNotValid)
+	}
+	if token == "" {
+		return nil, errors.New(messages.ErrConfigVaultTokenEmpty)
+	}
+	if key == "" {
+		return nil, errors.New(messages.ErrConfigVaultTransitKeyEmpty)
+	}
+
+	// Create the key ID from the mount path and the key name (the key versions are tracked by Vault itself).
+	mount = strings.Trim(mount, "/")
+	keyID := sha256.Sum256([]byte(mount + "/" + key))
+
+	return &VaultTransit{
+		id:      hex.EncodeToString(keyID[:])[:constants.ConstEncryptionKeyIDLength],
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		mount:   mount,
+		key:     key,
+		client: &http.Client{
+			Timeout: time.Duration(constants.ConstKeyProviderVaultRequestTimeout) * time.Second,
+		},
+	}, nil
+}
+
+// ID returns the key ID derived from the mount path and the key name.
+func (v *VaultTransit) ID() string {
+	return v.id
+}
+
+// Encrypt encrypts the given text with the Vault transit key, and returns the envelope
+// in the "v5:<key ID>:<Vault ciphertext>" format. The purpose is authenticated as associated data.
+func (v *VaultTransit) Encrypt(purpose, text string) (string, error) {
+	// Send the encrypt request to the Vault transit engine.
+	response, err := v.request("encrypt", &vaultTransitRequest{
+		Plaintext:      base64.StdEncoding.EncodeToString([]byte(text)),
+		AssociatedData: base64.StdEncoding.EncodeToString([]byte(purpose)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s:%s", constants.ConstEncryptionVaultCiphertextVersion, v.id, response.Data.Ciphertext), nil
+}
+
+// Decrypt decrypts the given envelope, which was encrypted by the Encrypt function, with the Vault transit key.
+func (v *VaultTransit) Decrypt(purpose, encryptedText string) (string, error) {
+	// Split the version prefix and the key ID from the Vault ciphertext.
+	parts := strings.SplitN(encryptedText, ":", 3)
+	if len(parts) != 3 || parts[0] != constants.ConstEncryptionVaultCiphertextVersion {
+		return "", errors.New(messages.ErrEncryptedTextVersionNotSupported)
+	}
+	if parts[1] != v.id {
+		return "", errors.New(messages.ErrEncryptionKeyIDNotFound)
+	}
+
+	// Send the decrypt request to the Vault transit engine.
+	response, err := v.request("decrypt", &vaultTransitRequest{
+		Ciphertext:     parts[2],
+		AssociatedData: base64.StdEncoding.EncodeToString([]byte(purpose)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Decode the plaintext.
+	text, err := base64.StdEncoding.DecodeString(response.Data.Plaintext)
+	if err != nil {
+		return "", errors.New(messages.ErrEncryptedTextNotValid)
+	}
+
+	return string(text), nil
+}
+
+// request sends the given request to the given operation endpoint of the Vault transit engine.
+func (v *VaultTransit) request(operation string, body *vaultTransitRequest) (*vaultTransitResponse, error) {
+	// Encode the request body.
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new request with the Vault token.
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("%s/v1/%s/%s/%s", v.address, v.mount, operation, url.PathEscape(v.key)),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request.
+	res, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Decode the response body (the error responses have the same format).
+	response := &vaultTransitResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil && res.StatusCode == http.StatusOK {
+		return nil, err
+	}
+
+	// Check the status of the response.
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(messages.ErrVaultRequestFailed, res.StatusCode, strings.Join(response.Errors, "; "))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,113 @@
+package keyring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/secretium/secretium/internal/config"
+	"github.com/secretium/secretium/internal/constants"
+)
+
+// newVaultTransitStub returns a stub HTTP server of the Vault transit engine, which "encrypts" the plaintext
+// by prefixing it with the associated data, and accepts only the given token.
+func newVaultTransitStub(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check the token.
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+			return
+		}
+
+		var body vaultTransitRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		response := &vaultTransitResponse{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/secretium":
+			response.Data.Ciphertext = "vault:v1:" + body.AssociatedData + "." + body.Plaintext
+		case "/v1/transit/decrypt/secretium":
+			associatedData, plaintext, _ := strings.Cut(strings.TrimPrefix(body.Ciphertext, "vault:v1:"), ".")
+			if associatedData != body.AssociatedData {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"cipher: message authentication failed"}})
+				return
+			}
+			response.Data.Plaintext = plaintext
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestVaultTransit(t *testing.T) {
+	server := newVaultTransitStub(t, "my-vault-token")
+	defer server.Close()
+
+	// Encrypt a value with the local key
+	oldKeyring, _ := New(&config.Config{SecretKey: "this-is-my-old-secret-key"})
+	oldEncrypted, _ := oldKeyring.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, "my old data key")
+
+	// Create the keyring with the Vault transit key as active and the local key as previous
+	vaultTransit, err := NewVaultTransitKeyProvider(server.URL, "my-vault-token", "/transit/", "secretium")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyring := &Keyring{Keys: append([]KeyProvider{vaultTransit}, oldKeyring.Keys...)}
+
+	// Test encrypting and decrypting with the Vault transit key
+	encrypted, err := keyring.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, "my data key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(encrypted, constants.ConstEncryptionVaultCiphertextVersion+":"+keyring.Active().ID()+":vault:v1:") {
+		t.Errorf("unexpected envelope, got: %v", encrypted)
+	}
+
+	if keyring.IsOutdated(encrypted) {
+		t.Errorf("unexpected outdated flag, got: %v, want: %v", true, false)
+	}
+
+	decrypted, err := keyring.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decrypted != "my data key" {
+		t.Errorf("unexpected decrypted text, got: %v, want: %v", decrypted, "my data key")
+	}
+
+	// Test decrypting with the other purpose (the purpose is authenticated as associated data)
+	if _, err := keyring.Decrypt(constants.ConstEncryptionKeyPurposeAccessCode, encrypted); err == nil {
+		t.Errorf("expected error for the other purpose, got: nil")
+	}
+
+	// Test decrypting the value of the previous local key
+	if !keyring.IsOutdated(oldEncrypted) {
+		t.Errorf("unexpected outdated flag, got: %v, want: %v", false, true)
+	}
+
+	if decrypted, err := keyring.Decrypt(constants.ConstEncryptionKeyPurposeDataKey, oldEncrypted); err != nil || decrypted != "my old data key" {
+		t.Errorf("unexpected decrypted text, got: %v (%v), want: %v", decrypted, err, "my old data key")
+	}
+
+	// Test encrypting with the wrong token
+	wrongToken, _ := NewVaultTransitKeyProvider(server.URL, "wrong-token", "transit", "secretium")
+	if _, err := wrongToken.Encrypt(constants.ConstEncryptionKeyPurposeDataKey, "my data key"); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("unexpected error for the wrong token, got: %v", err)
+	}
+
+	// Test creating the key provider without the settings
+	if _, err := NewVaultTransitKeyProvider("", "my-vault-token", "transit", "secretium"); err == nil {
+		t.Errorf("expected error for the empty address, got: nil")
+	}
+}
@@ -12,6 +12,30 @@ const (
 	// ErrConfigSecretKeyLengthNotValid is returned when the secret key is not valid.
 	ErrConfigSecretKeyLengthNotValid string = "secret key is not valid (length should be greater or equal to %d)"
 
+	// ErrConfigPreviousSecretKeyLengthNotValid is returned when one of the previous secret keys is not valid.
+	ErrConfigPreviousSecretKeyLengthNotValid string = "previous secret key #%d is not valid (length should be greater or equal to %d)"
+
+	// ErrConfigVariableFileConflict is returned when the configuration variable is set both directly and with the file.
+	ErrConfigVariableFileConflict string = "both %s and %s variables are set, please use only one of them"
+
+	// ErrConfigVariableFileNotReadable is returned when the file of the configuration variable does not exist or cannot be read.
+	ErrConfigVariableFileNotReadable string = "file of the %s variable does not exist or is not readable: %w"
+
+	// ErrConfigKeyProviderNotValid is returned when the key provider is not supported.
+	ErrConfigKeyProviderNotValid string = "key provider is not valid (should be one of: env, file, vault)"
+
+	// ErrConfigSecretKeyFileEmpty is returned when the path to the secret key file is empty.
+	ErrConfigSecretKeyFileEmpty string = "secret key file path is empty"
+
+	// ErrConfigVaultAddressNotValid is returned when the address of the Vault server is empty or not valid.
+	ErrConfigVaultAddressNotValid string = "vault address is empty or not valid"
+
+	// ErrConfigVaultTokenEmpty is returned when the token of the Vault server is empty.
+	ErrConfigVaultTokenEmpty string = "vault token is empty"
+
+	// ErrConfigVaultTransitKeyEmpty is returned when the key name of the Vault transit engine is empty.
+	ErrConfigVaultTransitKeyEmpty string = "vault transit key name is empty"
+
 	// ErrConfigMasterUsernameEmpty is returned when the master username is empty.
 	ErrConfigMasterUsernameEmpty string = "master username is empty"
 
@@ -42,6 +66,30 @@ const (
 	// ErrConfigServerWriteTimeoutNotValid is returned when the server write timeout is not valid.
 	ErrConfigServerWriteTimeoutNotValid string = "server write timeout is not valid"
 
+	// ErrConfigSecretTTLNotValid is returned when the minimum or maximum time to live of the secrets is not valid.
+	ErrConfigSecretTTLNotValid string = "secret minimum or maximum TTL is not valid (should be durations, like 5m or 720h, and the minimum should be from %ds to the maximum)"
+
+	// ErrConfigSecretMaxLifetimeNotValid is returned when the maximum total lifetime of the secrets is not valid.
+	ErrConfigSecretMaxLifetimeNotValid string = "secret maximum lifetime is not valid (should be zero for unlimited, or a duration greater or equal to the maximum TTL)"
+
+	// ErrConfigFailedAttemptsLimitNotValid is returned when the limit of the failed access code attempts is not valid.
+	ErrConfigFailedAttemptsLimitNotValid string = "failed attempts limit is not valid (should be a number greater or equal to zero, zero means unlimited)"
+
+	// ErrConfigFailedAttemptsActionNotValid is returned when the action of the failed access code attempts is not supported.
+	ErrConfigFailedAttemptsActionNotValid string = "failed attempts action is not valid (should be one of: lock, destroy)"
+
+	// ErrConfigJanitorModeNotValid is returned when the mode of the janitor is not supported.
+	ErrConfigJanitorModeNotValid string = "janitor mode is not valid (should be one of: off, delete, wipe)"
+
+	// ErrConfigJanitorRetentionPeriodNotValid is returned when the retention period of the janitor is not valid.
+	ErrConfigJanitorRetentionPeriodNotValid string = "janitor retention period is not valid (should be a duration greater or equal to zero, for example, 720h)"
+
+	// ErrConfigJanitorIntervalNotValid is returned when the interval of the janitor is not valid.
+	ErrConfigJanitorIntervalNotValid string = "janitor interval is not valid (should be a duration greater or equal to %ds, for example, 1h)"
+
+	// ErrConfigSecretFilesMaxSizeNotValid is returned when the maximum total size of the secret files is not valid.
+	ErrConfigSecretFilesMaxSizeNotValid string = "secret files maximum size is not valid (should be a number of MiB greater or equal to zero, zero disables files)"
+
 	/*
 		HTMX error messages.
 	*/
@@ -60,11 +108,106 @@ const (
 	ErrSecretKeyLengthNotValid string = "secret key is not valid (length should be strictly %d)"
 
 	// ErrSecretExpiresAtNotValid is returned when the secret expires at datetime is not valid.
-	ErrSecretExpiresAtNotValid string = "secret expires at datetime is not valid"
+	ErrSecretExpiresAtNotValid string = "secret expires at datetime is not valid (should be a duration, like 90m, 36h or 7d, or an exact datetime)"
+
+	// ErrSecretExpiresAtOutOfRange is returned when the secret expires at datetime is too soon or too late.
+	ErrSecretExpiresAtOutOfRange string = "secret expires at datetime is not valid (should be from %s to %s since now)"
+
+	// ErrSecretLifetimeExceeded is returned when the new or renewed secret would exceed the maximum total lifetime.
+	ErrSecretLifetimeExceeded string = "secret expires at datetime is not valid (the secret can live at most %s since its creation, until %s)"
+
+	// ErrSecretAvailableAtNotValid is returned when the secret available at datetime is not valid.
+	ErrSecretAvailableAtNotValid string = "secret available at datetime is not valid (should be a duration, like 90m, 36h or 7d, or an exact datetime)"
+
+	// ErrSecretAvailableAtOutOfRange is returned when the secret available at datetime is too late.
+	ErrSecretAvailableAtOutOfRange string = "secret available at datetime is not valid (should be at most %s since now)"
+
+	// ErrSecretIsNotUnique is returned when the key or access code of the new secret is already taken.
+	ErrSecretIsNotUnique string = "secret key or access code is already taken"
+
+	// ErrSecretSharesNotValid is returned when the shares of the split secret are not valid.
+	ErrSecretSharesNotValid string = "secret shares are not valid"
+
+	// ErrSecretIsSplit is returned when the split secret is unlocked by its own key instead of the share keys.
+	ErrSecretIsSplit string = "secret is split into shares, please unlock it with your share link"
+
+	// ErrRecipientPublicKeyNotValid is returned when the public key of the secret recipient is not valid.
+	ErrRecipientPublicKeyNotValid string = "recipient public key is not valid (should be an age X25519 recipient or an armored OpenPGP public key with an encryption key)"
+
+	// ErrSecretRecipientNotMatched is returned when the public key of the edited secret does not belong to its recipient.
+	ErrSecretRecipientNotMatched string = "recipient public key is empty or does not match the recipient of the secret"
+
+	// ErrSecretIsExpired is returned when the secret is expired.
+	ErrSecretIsExpired string = "secret is expired"
+
+	// ErrSecretIsNotAvailable is returned when the scheduled secret is not available for unlocking yet.
+	ErrSecretIsNotAvailable string = "secret is not available yet"
+
+	// ErrSecretIsLocked is returned when the secret is locked after too many failed access code attempts.
+	ErrSecretIsLocked string = "secret is locked after too many failed access code attempts"
+
+	// ErrSecretIsDestroyed is returned when the secret is destroyed after too many failed access code attempts.
+	ErrSecretIsDestroyed string = "secret is destroyed after too many failed access code attempts"
+
+	// ErrSecretIsWiped is returned when the encrypted fields of the expired secret were wiped by the janitor.
+	ErrSecretIsWiped string = "secret value was purged after the retention period"
+
+	// ErrSecretIsChanged is returned when the secret was changed or wiped since it was read for editing.
+	ErrSecretIsChanged string = "secret was changed by another request or purged, please reload it and try again"
+
+	// ErrSecretIsAlreadyExpired is returned when the secret selected for the bulk expire is already expired.
+	ErrSecretIsAlreadyExpired string = "secret is already expired"
 
 	// ErrSecretAccessCodeNotValid is returned when the secret access code is not valid.
 	ErrSecretAccessCodeNotValid string = "secret access code is not valid"
 
+	// ErrSecretRevealTokenNotValid is returned when the reveal token of the secret page is missing, not valid or expired.
+	ErrSecretRevealTokenNotValid string = "secret page is expired or was not opened in this browser, please reload the page and try again"
+
+	// ErrSecretFileNotGranted is returned when the file of the secret is downloaded without unlocking the secret in the same browser,
+	// or the download time is over.
+	ErrSecretFileNotGranted string = "secret file download link is expired or the secret was not unlocked in this browser, please unlock the secret again"
+
+	// ErrSecretAccessCodeNotReplaceable is returned when a new access code cannot be issued for the secret.
+	ErrSecretAccessCodeNotReplaceable string = "new secret access code cannot be issued, because the secret is protected by it (re-issue the secret instead)"
+
+	/*
+		Encryption error messages.
+	*/
+
+	// ErrEncryptedTextNotValid is returned when the encrypted text is malformed or was tampered with.
+	ErrEncryptedTextNotValid string = "encrypted text is not valid or was tampered with"
+
+	// ErrEncryptedStreamNotValid is returned when the encrypted stream (file) is malformed, truncated or was tampered with.
+	ErrEncryptedStreamNotValid string = "encrypted file is not valid, truncated or was tampered with"
+
+	// ErrEncryptedTextVersionNotSupported is returned when the version of the encrypted text is not supported.
+	ErrEncryptedTextVersionNotSupported string = "encrypted text version is not supported"
+
+	// ErrEncryptionKeyIDNotFound is returned when the key ID of the encrypted text is not found in the keyring.
+	ErrEncryptionKeyIDNotFound string = "encryption key ID is not found in the keyring"
+
+	// ErrVaultRequestFailed is returned when the request to the Vault transit engine failed.
+	ErrVaultRequestFailed string = "vault transit request failed (status %d): %s"
+
+	// ErrAccessCodePolicyTypeNotValid is returned when the access code policy type is not valid.
+	ErrAccessCodePolicyTypeNotValid string = "access code type is not valid (should be 'characters' or 'passphrase')"
+
+	// ErrAccessCodePolicyLengthNotValid is returned when the access code policy length is not valid.
+	ErrAccessCodePolicyLengthNotValid string = "access code length should be a number between %d and %d"
+
+	// ErrAccessCodePolicyCharacterClassesNotValid is returned when the access code policy character classes are not valid.
+	ErrAccessCodePolicyCharacterClassesNotValid string = "access code character classes should be a list of 'lower', 'upper', 'digits' or 'symbols' (not more than the length)"
+
+	// ErrAccessCodePolicyWordsNotValid is returned when the access code policy number of words is not valid.
+	ErrAccessCodePolicyWordsNotValid string = "access code passphrase words should be a number between %d and %d"
+
+	// ErrAccessCodePolicyWordSeparatorNotValid is returned when the access code policy word separator is not valid.
+	ErrAccessCodePolicyWordSeparatorNotValid string = "access code passphrase word separator should be one of '-', '.', '_' or space"
+
+	// ErrAccessCodeHashNotValid is returned when the encoded hash of the access code is not valid.
+	ErrAccessCodeHashNotValid string = "access code hash is not valid"
+
 	/*
 		Form error messages.
 	*/
@@ -75,15 +218,54 @@ const (
 	// ErrFormSignInUserCredentialsNotValid is returned when the sign in user credentials are not valid.
 	ErrFormLoginUserCredentialsNotValid string = "master username or password are empty or not valid"
 
+	// ErrFormBulkActionNotValid is returned when the bulk action is not valid.
+	ErrFormBulkActionNotValid string = "bulk action is not valid (should be 'expire', 'renew', 'delete' or 'purge-expired')"
+
+	// ErrFormBulkKeysNotValid is returned when the number of the secrets selected for the bulk action is not valid.
+	ErrFormBulkKeysNotValid string = "selected secrets are not valid (select at least 1 and at most %d secrets)"
+
 	// ErrFormAddSecretNameLengthNotValid is returned when the secret name is not valid.
 	ErrFormAddSecretNameLengthNotValid string = "secret name is not valid (length should be greater than %d and less than %d)"
 
 	// ErrFormAddSecretValueLengthNotValid is returned when the secret value is not valid.
 	ErrFormAddSecretValueLengthNotValid string = "secret value is not valid (length should be greater or equal to %d)"
 
+	// ErrFormAddSecretMaxViewsNotValid is returned when the maximum number of views of the secret is not valid.
+	ErrFormAddSecretMaxViewsNotValid string = "secret views are not valid (should be between 1 and %d, or empty for unlimited views)"
+
+	// ErrFormEditSecretValueNotEditable is returned when the value of the secret cannot be re-encrypted by the server.
+	ErrFormEditSecretValueNotEditable string = "secret value cannot be edited, because it is encrypted in the browser or split into shares (only the name can be edited)"
+
+	// ErrFormAddSecretClientEncryptedValueNotValid is returned when the secret value encrypted in the browser is not valid.
+	ErrFormAddSecretClientEncryptedValueNotValid string = "secret value is not encrypted in the browser (zero-knowledge mode requires JavaScript and a secure context)"
+
 	// ErrFormAddSecretAccessCodeLengthNotValid is returned when the secret access code is not valid.
 	ErrFormAddSecretAccessCodeLengthNotValid string = "secret access code is not valid (length should be greater than %d and less than %d)"
 
+	// ErrFormAddSecretAccessCodeNotStrong is returned when the custom secret access code is too weak.
+	ErrFormAddSecretAccessCodeNotStrong string = "secret access code is too weak (estimated strength is %.0f bits, should be at least %.0f bits), add more random characters or words"
+
+	// ErrFormAddSecretSharesNotValid is returned when the number of shares or the threshold of the split secret is not valid.
+	ErrFormAddSecretSharesNotValid string = "secret shares are not valid (at least %d and at most %d shares, and the number of required shares should be between %d and the number of shares)"
+
+	// ErrFormAddSecretRecipientPublicKeyLengthNotValid is returned when the recipient public key is too long.
+	ErrFormAddSecretRecipientPublicKeyLengthNotValid string = "recipient public key is not valid (length should be less than %d)"
+
+	// ErrFormAddSecretRecipientNotCompatible is returned when the secret encrypted in the browser has a recipient public key.
+	ErrFormAddSecretRecipientNotCompatible string = "secret encrypted in the browser cannot be encrypted to the recipient public key"
+
+	// ErrFormAddSecretSharesNotCompatible is returned when the split secret is combined with an incompatible option.
+	ErrFormAddSecretSharesNotCompatible string = "split secret cannot be encrypted in the browser, protected with the access code or have a custom access code"
+
+	// ErrFormAddSecretFilesNotEnabled is returned when the files are attached to the secret, but the files are disabled.
+	ErrFormAddSecretFilesNotEnabled string = "secret files are disabled on this server"
+
+	// ErrFormAddSecretFilesNotValid is returned when the number or the total size of the secret files is not valid.
+	ErrFormAddSecretFilesNotValid string = "secret files are not valid (at most %d files with the total size of %s)"
+
+	// ErrFormAddSecretFilesNotCompatible is returned when the files are attached to the secret with an incompatible option.
+	ErrFormAddSecretFilesNotCompatible string = "secret files cannot be attached to the secret encrypted in the browser, split into shares or encrypted to the recipient public key"
+
 	/*
 		Session error messages.
 	*/
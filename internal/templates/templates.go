@@ -1,8 +1,11 @@
 package templates
 
 import (
+	"time"
+
 	"github.com/a-h/templ"
 	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/helpers"
 )
 
 // TemplateOptions is the options for the template.
@@ -23,5 +26,15 @@ type ElementStyle struct {
 type DashboardComponentOptions struct {
 	State, Username, ShareURL string
 	Secret                    *database.Secret
+	AccessCodePolicy          *helpers.AccessCodePolicy
+	MinTTL, MaxTTL            time.Duration
+	FilesMaxSize              int64
+	Shares                    []*DashboardSecretShare
 	Data                      map[string]string
 }
+
+// DashboardSecretShare is the share of the split secret for the dashboard component.
+type DashboardSecretShare struct {
+	Number               int
+	ShareURL, AccessCode string
+}
@@ -2,14 +2,21 @@ package main
 
 import (
 	"log/slog"
+	"os"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs the application or the given command, and returns the exit code.
+// The DB connection is closed before the exit code is returned.
+func run() int {
 	// Initialize application.
 	app, err := initializeApplication()
 	if err != nil {
 		slog.Error("failed to initialize application", "details", err.Error())
-		return
+		return 1
 	}
 
 	// Make sure to close the DB connection when the application exits.
@@ -21,9 +28,27 @@ func main() {
 		}
 	}()
 
+	// Run the given command.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "rotate-keys":
+			// Re-encrypt all secrets with the active key.
+			if err := app.RotateKeys(); err != nil {
+				slog.Error("failed to rotate keys", "details", err.Error())
+				return 1
+			}
+		default:
+			slog.Error("unknown command", "command", os.Args[1])
+			return 1
+		}
+		return 0
+	}
+
 	// Run application.
 	if err := app.Run(); err != nil {
 		slog.Error("failed to run application", "details", err.Error())
-		return
+		return 1
 	}
+
+	return 0
 }
@@ -22,9 +22,15 @@ services:
       - '8787'
     # Set required environment variables for the backend.
     environment:
-      SECRET_KEY: /run/secrets/secretium_key
-      MASTER_USERNAME: /run/secrets/secretium_master_username
-      MASTER_PASSWORD: /run/secrets/secretium_master_password
+      # Each variable can also be read from a file with the '_FILE' suffix (for example, SECRET_KEY_FILE).
+      SECRET_KEY_FILE: /run/secrets/secretium_key
+      MASTER_USERNAME_FILE: /run/secrets/secretium_master_username
+      MASTER_PASSWORD_FILE: /run/secrets/secretium_master_password
+    # Set the Docker secrets for the container (mounted to the '/run/secrets' folder).
+    secrets:
+      - secretium_key
+      - secretium_master_username
+      - secretium_master_password
     # Set volumes for the container with SQLite data and the root SSL certificates.
     volumes:
       - ./secretium-data:/secretium-data
@@ -50,9 +56,5 @@ EOL
 echo "Run the 'docker-compose up -d' command to start the container..."
 docker-compose up -d
 
-# Remove the TXT files with the sensitive data.
-echo "Remove the TXT files with the sensitive data..."
-rm secretium_key.txt secretium_master_username.txt secretium_master_password.txt secretium_domain.txt
-
 # Final words.
 echo "All tasks done!"
\ No newline at end of file
@@ -11,6 +11,7 @@ import (
 	"github.com/secretium/secretium/internal/attachments"
 	"github.com/secretium/secretium/internal/config"
 	"github.com/secretium/secretium/internal/database"
+	"github.com/secretium/secretium/internal/keyring"
 	"github.com/secretium/secretium/internal/session"
 )
 
@@ -27,7 +28,11 @@ func initializeApplication() (*application.Application, error) {
 	if err != nil {
 		return nil, err
 	}
+	keyringKeyring, err := keyring.New(configConfig)
+	if err != nil {
+		return nil, err
+	}
 	sessionSession := session.New(configConfig)
-	applicationApplication := application.New(attachmentsAttachments, configConfig, databaseDatabase, sessionSession)
+	applicationApplication := application.New(attachmentsAttachments, configConfig, databaseDatabase, keyringKeyring, sessionSession)
 	return applicationApplication, nil
 }